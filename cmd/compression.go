@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"strings"
@@ -17,73 +18,134 @@ var compressionCmd = &cobra.Command{
 	Long:  `Handle Sega Genesis / Mega Drive ROMs compression`,
 }
 
-var segardCompressionCmd = &cobra.Command{
-	Use:   "segard",
-	Short: "Handle Sega Genesis / Mega Drive ROMs \"SEGARD\" compression",
-	Long: `Handle Sega Genesis / Mega Drive ROMs \"SEGARD\" compression
-Games where this compression is found:
-	- [SMD] Alex Kidd in Enchanted Castle
-	- [SMD] Altered Beast
-	- [SMD] Columns
-	- [SMD] Golden Axe
-	- [SMD] Hokuto no Ken: Shin Seikimatsu Kyuuseishu Densetsu
-	- [SMD] Last Battle
-	- [SMD] Osomatsu-kun - Hachamecha Gekijou
-	- [SMD] World Championship Soccer`,
-	Args:       cobra.MinimumNArgs(3),
-	ValidArgs:  []string{"mode", "input", "output"},
-	ArgAliases: []string{"mode", "input", "output"},
-	PreRun: func(cmd *cobra.Command, args []string) {
-		switch args[0] {
-		case "decompress":
-		case "compress":
-		default:
-			log.Fatal("Invalid mode. Valid modes: decompress, compress")
+// newCompressionCmd creates the subcommand that compresses or decompresses data with the given algorithm.
+//
+// Parameters:
+// - algorithm: the algorithm as listed in types.MDCompressorAlgorithms.
+//
+// Returns:
+// - *cobra.Command: a pointer to the newly created command.
+func newCompressionCmd(algorithm types.MDCompressorAlgorithm) *cobra.Command {
+	long := fmt.Sprintf("Handle Sega Genesis / Mega Drive ROMs \"%s\" compression", algorithm.Name)
+	if len(algorithm.Games) > 0 {
+		long += "\nGames where this compression is found:"
+		for _, game := range algorithm.Games {
+			long += "\n\t- " + game
 		}
+	}
+	aliases := make([]string, 0, len(algorithm.Aliases))
+	for _, alias := range algorithm.Aliases {
+		aliases = append(aliases, strings.ToLower(alias))
+	}
 
-		if _, err := os.Stat(args[1]); os.IsNotExist(err) {
-			log.Fatal(err)
-		}
+	command := &cobra.Command{
+		Use:        strings.ToLower(algorithm.Name),
+		Aliases:    aliases,
+		Short:      fmt.Sprintf("Handle Sega Genesis / Mega Drive ROMs \"%s\" compression", algorithm.Name),
+		Long:       long,
+		Args:       cobra.MinimumNArgs(3),
+		ValidArgs:  []string{"mode", "input", "output"},
+		ArgAliases: []string{"mode", "input", "output"},
+		PreRun: func(cmd *cobra.Command, args []string) {
+			switch args[0] {
+			case "decompress":
+			case "compress":
+			default:
+				log.Fatal("Invalid mode. Valid modes: decompress, compress")
+			}
+
+			if _, err := os.Stat(args[1]); os.IsNotExist(err) {
+				log.Fatal(err)
+			}
 
-		split := strings.Split(args[2], string(os.PathSeparator))
-		if len(split[:len(split)-1]) > 0 {
-			path := strings.Join(split[:len(split)-1], string(os.PathSeparator))
-			if _, err := os.Stat(path); os.IsNotExist(err) {
-				if err := os.MkdirAll(path, 0777); err != nil {
+			split := strings.Split(args[2], string(os.PathSeparator))
+			if len(split[:len(split)-1]) > 0 {
+				path := strings.Join(split[:len(split)-1], string(os.PathSeparator))
+				if _, err := os.Stat(path); os.IsNotExist(err) {
+					if err := os.MkdirAll(path, 0777); err != nil {
+						log.Fatal(err)
+					}
+				}
+			}
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			var in *generic.ROM
+			var out *os.File
+			var err error
+			var data []byte
+			var offset, size int
+			if offset, err = cmd.Flags().GetInt("offset"); err != nil {
+				log.Fatal(err)
+			}
+			if in, err = generic.NewROM(args[1]); err != nil {
+				log.Fatal(err)
+			}
+			parameters := make(map[string]int)
+			for _, parameter := range algorithm.Parameters {
+				if parameters[parameter.Name], err = compressionParameter(cmd, parameter); err != nil {
 					log.Fatal(err)
 				}
 			}
-		}
-	},
-	Run: func(cmd *cobra.Command, args []string) {
-		var in *generic.ROM
-		var out *os.File
-		var err error
-		var data []byte
-		if in, err = generic.NewROM(args[1]); err != nil {
-			log.Fatal(err)
-		}
-		if out, err = os.Create(args[2]); err != nil {
-			log.Fatal(err)
-		}
-		compressor := types.NewMDCompressor("SEGARD", *in)
-		switch args[0] {
-		case "decompress":
-			data = compressor.Unmarshal()
-		case "compress":
-			data = compressor.Marshal()
-		}
-		if len(data) > 0 {
+			switch args[0] {
+			case "decompress":
+				if offset < 0 || offset >= in.Size {
+					log.Fatalf("Offset 0x%X is outside of %s", offset, args[1])
+				}
+				data, size = algorithm.New(*in, parameters).UnmarshalAt(offset)
+				fmt.Fprintf(cmd.OutOrStdout(), "Decompressed 0x%X bytes from 0x%X bytes at offset 0x%X\n", len(data), size, offset)
+			case "compress":
+				if offset < 0 || offset > in.Size {
+					log.Fatalf("Offset 0x%X is outside of %s", offset, args[1])
+				}
+				in.Data = in.Data[offset:]
+				in.Size = len(in.Data)
+				data = algorithm.New(*in, parameters).Marshal()
+			}
+			if len(data) == 0 {
+				log.Fatalf("Unable to %s data", args[0])
+			}
+			if out, err = os.Create(args[2]); err != nil {
+				log.Fatal(err)
+			}
 			if _, err = out.Write(data); err != nil {
 				log.Fatal(err)
 			}
+		},
+	}
+	command.Flags().IntP("offset", "o", 0, "offset of the input where the data starts")
+	for _, parameter := range algorithm.Parameters {
+		if parameter.Switch {
+			command.Flags().Bool(parameter.Name, false, parameter.Usage)
 		} else {
-			log.Fatalf("Unable to %s data", args[0])
+			command.Flags().Int(parameter.Name, 0, parameter.Usage)
 		}
-	},
+	}
+	return command
+}
+
+// compressionParameter reads the value of an algorithm parameter from the flags of the command.
+//
+// Parameters:
+// - cmd: the command holding the flags.
+// - parameter: the algorithm parameter to read.
+//
+// Returns:
+// - int: the value of the parameter, being 1 for a switch that is set.
+// - error: an error if the flag can not be read.
+func compressionParameter(cmd *cobra.Command, parameter types.MDCompressorParameter) (int, error) {
+	if !parameter.Switch {
+		return cmd.Flags().GetInt(parameter.Name)
+	}
+	set, err := cmd.Flags().GetBool(parameter.Name)
+	if set {
+		return 1, err
+	}
+	return 0, err
 }
 
 func init() {
-	compressionCmd.AddCommand(segardCompressionCmd)
+	for _, algorithm := range types.MDCompressorAlgorithms {
+		compressionCmd.AddCommand(newCompressionCmd(algorithm))
+	}
 	rootCmd.AddCommand(compressionCmd)
 }
@@ -2,11 +2,37 @@ package cmd_test
 
 import (
 	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/hansbonini/go-segamd/cmd"
+	"github.com/hansbonini/go-segamd/types"
+	"github.com/hansbonini/go-segamd/types/generic"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
+// resetFlags restores every flag of the command and its subcommands to its default value, since
+// cmd.RootCmd is shared by the tests and cobra keeps the parsed flags between executions.
+func resetFlags(t *testing.T, c *cobra.Command) {
+	t.Helper()
+	reset := func(flag *pflag.Flag) {
+		if err := flag.Value.Set(flag.DefValue); err != nil {
+			t.Fatal(err)
+		}
+		flag.Changed = false
+	}
+	c.Flags().VisitAll(reset)
+	c.PersistentFlags().VisitAll(reset)
+	for _, child := range c.Commands() {
+		resetFlags(t, child)
+	}
+}
+
 func TestCompressionCmd(t *testing.T) {
 	buf := new(bytes.Buffer)
 	c := cmd.RootCmd
@@ -16,3 +42,147 @@ func TestCompressionCmd(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestCompressionAlgorithmCmds(t *testing.T) {
+	input := bytes.Repeat([]byte{0x00, 0x11, 0x11, 0x22, 0x22, 0x22, 0x33, 0x44}, 0x40)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "input.bin")
+	if err := os.WriteFile(path, input, 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, algorithm := range types.MDCompressorAlgorithms {
+		t.Run(algorithm.Name, func(t *testing.T) {
+			name := strings.ToLower(algorithm.Name)
+			compressed := filepath.Join(dir, name, "compressed.bin")
+			decompressed := filepath.Join(dir, name, "decompressed.bin")
+
+			c := cmd.RootCmd
+			c.SetOutput(new(bytes.Buffer))
+			resetFlags(t, c)
+			c.SetArgs([]string{"compression", name, "compress", path, compressed})
+			if err := c.Execute(); err != nil {
+				t.Fatal(err)
+			}
+			resetFlags(t, c)
+			c.SetArgs([]string{"compression", name, "decompress", compressed, decompressed})
+			if err := c.Execute(); err != nil {
+				t.Fatal(err)
+			}
+			got, err := os.ReadFile(decompressed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, input) {
+				t.Errorf("decompress(compress()) = %X, want %X", got, input)
+			}
+		})
+	}
+}
+
+func TestCompressionAlgorithmCmdHelp(t *testing.T) {
+	buf := new(bytes.Buffer)
+	c := cmd.RootCmd
+	resetFlags(t, c)
+	c.SetArgs([]string{"compression", "kozinski", "--help"})
+	c.SetOutput(buf)
+	if err := c.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "[SMD] Sonic the Hedgehog 2") {
+		t.Errorf("help does not list the games using KOSINSKI:\n%s", buf.String())
+	}
+}
+
+func TestCompressionAlgorithmCmdOffset(t *testing.T) {
+	input := bytes.Repeat([]byte("SONIC THE HEDGEHOG "), 0x10)
+	rom := generic.ROM{Data: input, Size: len(input)}
+	compressed := types.NewMDCompressor("KOSINSKI", rom).Marshal()
+	padding := bytes.Repeat([]byte{0xFF}, 0x10)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rom.bin")
+	if err := os.WriteFile(path, append(append(padding, compressed...), padding...), 0666); err != nil {
+		t.Fatal(err)
+	}
+	decompressed := filepath.Join(dir, "decompressed.bin")
+
+	buf := new(bytes.Buffer)
+	c := cmd.RootCmd
+	c.SetOutput(buf)
+	resetFlags(t, c)
+	c.SetArgs([]string{"compression", "kosinski", "decompress", "--offset", "0x10", path, decompressed})
+	if err := c.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(decompressed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, input) {
+		t.Errorf("decompress --offset 0x10 = %X, want %X", got, input)
+	}
+	want := fmt.Sprintf("Decompressed 0x%X bytes from 0x%X bytes at offset 0x10", len(input), len(compressed))
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("decompress --offset 0x10 printed %q, want %q", buf.String(), want)
+	}
+}
+
+func TestCompressionAlgorithmCmdEmptyInput(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "empty.bin")
+	if err := os.WriteFile(path, []byte{}, 0666); err != nil {
+		t.Fatal(err)
+	}
+	compressed := filepath.Join(dir, "compressed.bin")
+
+	c := cmd.RootCmd
+	c.SetOutput(new(bytes.Buffer))
+	resetFlags(t, c)
+	c.SetArgs([]string{"compression", "kosinski", "compress", path, compressed})
+	if err := c.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := os.ReadFile(compressed); err != nil || len(got) == 0 {
+		t.Errorf("compress of an empty input wrote %X, %v", got, err)
+	}
+}
+
+func TestCompressionAlgorithmCmdParameters(t *testing.T) {
+	input := bytes.Repeat([]byte("SONIC THE HEDGEHOG 2 "), 0x10)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "input.bin")
+	if err := os.WriteFile(path, input, 0666); err != nil {
+		t.Fatal(err)
+	}
+	compressed := filepath.Join(dir, "compressed.bin")
+	decompressed := filepath.Join(dir, "decompressed.bin")
+
+	c := cmd.RootCmd
+	c.SetOutput(new(bytes.Buffer))
+	resetFlags(t, c)
+	c.SetArgs([]string{"compression", "saxman", "compress", "--headerless", path, compressed})
+	if err := c.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	resetFlags(t, c)
+	c.SetArgs([]string{"compression", "saxman", "decompress", "--headerless", compressed, decompressed})
+	if err := c.Execute(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(compressed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rom := generic.ROM{Data: input, Size: len(input)}
+	if want := (&types.MDCompressor_SAXMAN{ROM: rom, Headerless: true}).Marshal(); !bytes.Equal(data, want) {
+		t.Errorf("compress --headerless = %X, want %X", data, want)
+	}
+	got, err := os.ReadFile(decompressed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, input) {
+		t.Errorf("decompress --headerless = %X, want %X", got, input)
+	}
+}
@@ -13,5 +13,5 @@ require github.com/go-audio/riff v1.0.0 // indirect
 require (
 	github.com/go-audio/audio v1.0.0
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
-	github.com/spf13/pflag v1.0.5 // indirect
+	github.com/spf13/pflag v1.0.5
 )
@@ -0,0 +1,310 @@
+package types
+
+import (
+	"bytes"
+
+	"github.com/hansbonini/go-segamd/types/generic"
+)
+
+type mdBitReader struct {
+	rom   *generic.ROM
+	start int
+	bit   int
+}
+
+type mdBitWriter struct {
+	out   *bytes.Buffer
+	value uint32
+	count int
+}
+
+// newMDBitReader creates a reader that fetches bits MSB first from the ROM,
+// starting at its current offset.
+//
+// Parameters:
+// - rom: a pointer to the generic.ROM holding the bitstream.
+//
+// Returns:
+// - *mdBitReader: a pointer to the newly created reader.
+func newMDBitReader(rom *generic.ROM) *mdBitReader {
+	return &mdBitReader{
+		rom:   rom,
+		start: rom.Offset,
+	}
+}
+
+// Peek returns the next n bits of the stream without consuming them.
+//
+// Bits past the end of the ROM are read as zero, so callers must check
+// Exhausted to know when the stream is over.
+//
+// Parameters:
+// - n: the number of bits to return, up to 24.
+//
+// Returns:
+// - uint32: the requested bits, right aligned.
+func (br *mdBitReader) Peek(n int) (value uint32) {
+	for i := 0; i < n; i++ {
+		pos := br.start*8 + br.bit + i
+		value <<= 1
+		if pos/8 < br.rom.Size {
+			value |= uint32(br.rom.Data[pos/8]>>(7-pos%8)) & 0x01
+		}
+	}
+	return
+}
+
+// Skip consumes n bits and moves the ROM offset to the byte holding the last one.
+//
+// Parameters:
+// - n: the number of bits to consume.
+func (br *mdBitReader) Skip(n int) {
+	br.bit += n
+	br.rom.Offset = br.start + (br.bit+7)/8
+}
+
+// Read consumes the next n bits of the stream.
+//
+// Parameters:
+// - n: the number of bits to read, up to 24.
+//
+// Returns:
+// - uint32: the read bits, right aligned.
+func (br *mdBitReader) Read(n int) (value uint32) {
+	value = br.Peek(n)
+	br.Skip(n)
+	return
+}
+
+// Exhausted reports whether every bit of the ROM has been consumed.
+//
+// Returns:
+// - bool: true if the reader is past the end of the ROM.
+func (br *mdBitReader) Exhausted() bool {
+	return br.start*8+br.bit >= br.rom.Size*8
+}
+
+// newMDBitWriter creates a writer that packs bits MSB first into the given buffer.
+//
+// Parameters:
+// - out: the buffer receiving the packed bytes.
+//
+// Returns:
+// - *mdBitWriter: a pointer to the newly created writer.
+func newMDBitWriter(out *bytes.Buffer) *mdBitWriter {
+	return &mdBitWriter{
+		out: out,
+	}
+}
+
+// Write appends the n lowest bits of value to the stream.
+//
+// Parameters:
+// - value: the bits to write, right aligned.
+// - n: the number of bits to write, up to 24.
+func (bw *mdBitWriter) Write(value uint32, n int) {
+	for i := n - 1; i >= 0; i-- {
+		bw.value = bw.value<<1 | (value>>i)&0x01
+		bw.count++
+		if bw.count == 8 {
+			bw.out.WriteByte(byte(bw.value))
+			bw.value = 0
+			bw.count = 0
+		}
+	}
+}
+
+// Flush pads the last partial byte with zero bits and writes it out.
+func (bw *mdBitWriter) Flush() {
+	if bw.count > 0 {
+		bw.Write(0, 8-bw.count)
+	}
+}
+
+type mdFlagFormat struct {
+	Width        int
+	LSBFirst     bool
+	LittleEndian bool
+	Eager        bool
+}
+
+type mdFlagReader struct {
+	rom    *generic.ROM
+	format mdFlagFormat
+	flags  uint32
+	count  int
+}
+
+type mdFlagWriter struct {
+	out     *bytes.Buffer
+	format  mdFlagFormat
+	flags   uint32
+	count   int
+	pending bytes.Buffer
+	open    bool
+}
+
+// newMDFlagReader creates a reader for the descriptor fields interleaved with data bytes
+// used by most LZ based formats.
+//
+// Parameters:
+// - rom: a pointer to the generic.ROM holding the stream.
+// - format: the layout of the descriptor fields.
+//
+// Returns:
+// - *mdFlagReader: a pointer to the newly created reader.
+func newMDFlagReader(rom *generic.ROM, format mdFlagFormat) *mdFlagReader {
+	return &mdFlagReader{
+		rom:    rom,
+		format: format,
+	}
+}
+
+// load fetches the next descriptor field from the ROM.
+//
+// Returns:
+// - error: an error if the end of the ROM is reached.
+func (fr *mdFlagReader) load() (err error) {
+	var value uint8
+	fr.flags = 0
+	for i := 0; i < fr.format.Width/8; i++ {
+		if value, err = fr.rom.Read8(); err != nil {
+			return err
+		}
+		if fr.format.LittleEndian {
+			fr.flags |= uint32(value) << (i * 8)
+		} else {
+			fr.flags = fr.flags<<8 | uint32(value)
+		}
+	}
+	fr.count = fr.format.Width
+	return nil
+}
+
+// ReadBit returns the next bit of the descriptor field, fetching a new field when needed.
+//
+// Lazy formats fetch a new field right before its first bit is needed, while eager
+// formats fetch it as soon as the last bit of the previous field is consumed.
+//
+// Returns:
+// - uint8: the read bit.
+// - error: an error if the end of the ROM is reached.
+func (fr *mdFlagReader) ReadBit() (bit uint8, err error) {
+	if fr.count == 0 {
+		if err = fr.load(); err != nil {
+			return 0, err
+		}
+	}
+	fr.count--
+	if fr.format.LSBFirst {
+		bit = uint8(fr.flags>>(fr.format.Width-1-fr.count)) & 0x01
+	} else {
+		bit = uint8(fr.flags>>fr.count) & 0x01
+	}
+	if fr.count == 0 && fr.format.Eager {
+		fr.load()
+	}
+	return bit, nil
+}
+
+// ReadBits returns the next n bits of the descriptor fields, first bit read as the most significant.
+//
+// Parameters:
+// - n: the number of bits to read.
+//
+// Returns:
+// - uint32: the read bits.
+// - error: an error if the end of the ROM is reached.
+func (fr *mdFlagReader) ReadBits(n int) (value uint32, err error) {
+	var bit uint8
+	for i := 0; i < n; i++ {
+		if bit, err = fr.ReadBit(); err != nil {
+			return value, err
+		}
+		value = value<<1 | uint32(bit)
+	}
+	return value, nil
+}
+
+// newMDFlagWriter creates a writer that interleaves descriptor fields with data bytes.
+//
+// Parameters:
+// - out: the buffer receiving the stream.
+// - format: the layout of the descriptor fields.
+//
+// Returns:
+// - *mdFlagWriter: a pointer to the newly created writer.
+func newMDFlagWriter(out *bytes.Buffer, format mdFlagFormat) *mdFlagWriter {
+	return &mdFlagWriter{
+		out:    out,
+		format: format,
+	}
+}
+
+// WriteBit appends a bit to the current descriptor field.
+//
+// Parameters:
+// - bit: the bit to write.
+func (fw *mdFlagWriter) WriteBit(bit uint8) {
+	if fw.count == fw.format.Width {
+		fw.flush()
+	}
+	fw.open = true
+	if bit != 0 {
+		if fw.format.LSBFirst {
+			fw.flags |= 1 << fw.count
+		} else {
+			fw.flags |= 1 << (fw.format.Width - 1 - fw.count)
+		}
+	}
+	fw.count++
+	if fw.count == fw.format.Width && fw.format.Eager {
+		fw.flush()
+		fw.open = true
+	}
+}
+
+// WriteBits appends the n lowest bits of value to the descriptor fields, most significant first.
+//
+// Parameters:
+// - value: the bits to write.
+// - n: the number of bits to write.
+func (fw *mdFlagWriter) WriteBits(value uint32, n int) {
+	for i := n - 1; i >= 0; i-- {
+		fw.WriteBit(uint8(value>>i) & 0x01)
+	}
+}
+
+// WriteByte appends a data byte after the current descriptor field.
+//
+// Parameters:
+// - value: the byte to write.
+//
+// Returns:
+// - error: always nil.
+func (fw *mdFlagWriter) WriteByte(value byte) error {
+	return fw.pending.WriteByte(value)
+}
+
+// flush writes the current descriptor field followed by its data bytes.
+func (fw *mdFlagWriter) flush() {
+	for i := 0; i < fw.format.Width/8; i++ {
+		if fw.format.LittleEndian {
+			fw.out.WriteByte(byte(fw.flags >> (i * 8)))
+		} else {
+			fw.out.WriteByte(byte(fw.flags >> (fw.format.Width - 8 - i*8)))
+		}
+	}
+	fw.out.Write(fw.pending.Bytes())
+	fw.pending.Reset()
+	fw.flags = 0
+	fw.count = 0
+	fw.open = false
+}
+
+// Close writes the last descriptor field, if the decoder expects one, and its data bytes.
+func (fw *mdFlagWriter) Close() {
+	if fw.open || fw.pending.Len() > 0 {
+		fw.flush()
+	}
+}
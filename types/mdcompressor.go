@@ -13,108 +13,144 @@ import (
 type MDCompressor interface {
 	Marshal() []byte
 	Unmarshal() []byte
+	// UnmarshalAt decodes the data starting at the given offset of the ROM, and also returns how many
+	// bytes of the ROM it consumed: every byte from the offset up to and including the last one the
+	// format needs, be it an end marker or the command completing the size given by a header. Where
+	// the data itself doesn't tell where it ends, as with a headerless SAXMAN stream of unknown size,
+	// the rest of the ROM is consumed. An offset outside of the ROM consumes nothing.
+	UnmarshalAt(offset int) ([]byte, int)
+}
+
+type MDCompressorParameter struct {
+	Name   string
+	Usage  string
+	Switch bool
+}
+
+type MDCompressorAlgorithm struct {
+	Name       string
+	Aliases    []string
+	Games      []string
+	Parameters []MDCompressorParameter
+	New        func(rom generic.ROM, parameters map[string]int) MDCompressor
+}
+
+// MDCompressorAlgorithms lists every algorithm known by NewMDCompressor, along with the games where
+// it is found, the parameters it accepts and how to create it. Switch parameters are 1 when set.
+var MDCompressorAlgorithms = []MDCompressorAlgorithm{
+	{
+		Name: "SEGARD",
+		Games: []string{
+			"[SMD] Alex Kidd in Enchanted Castle",
+			"[SMD] Altered Beast",
+			"[SMD] Columns",
+			"[SMD] Golden Axe",
+			"[SMD] Hokuto no Ken: Shin Seikimatsu Kyuuseishu Densetsu",
+			"[SMD] Last Battle",
+			"[SMD] Osomatsu-kun - Hachamecha Gekijou",
+			"[SMD] World Championship Soccer",
+		},
+		New: func(rom generic.ROM, parameters map[string]int) MDCompressor {
+			return &MDCompressor_SEGARD{ROM: rom}
+		},
+	},
+	{
+		Name: "NEMESIS",
+		Games: []string{
+			"[SMD] Sonic the Hedgehog",
+			"[SMD] Sonic the Hedgehog 2",
+			"[SMD] Sonic the Hedgehog 3",
+			"[SMD] Streets of Rage",
+		},
+		New: func(rom generic.ROM, parameters map[string]int) MDCompressor {
+			return &MDCompressor_NEMESIS{ROM: rom}
+		},
+	},
+	{
+		Name:    "KOSINSKI",
+		Aliases: []string{"KOZINSKI"},
+		Games: []string{
+			"[SMD] Sonic the Hedgehog",
+			"[SMD] Sonic the Hedgehog 2",
+			"[SMD] Sonic the Hedgehog 3",
+		},
+		New: func(rom generic.ROM, parameters map[string]int) MDCompressor {
+			return &MDCompressor_KOZINSKI{ROM: rom}
+		},
+	},
+	{
+		Name: "KOSINSKIM",
+		Games: []string{
+			"[SMD] Sonic & Knuckles",
+			"[SMD] Sonic the Hedgehog 3",
+		},
+		New: func(rom generic.ROM, parameters map[string]int) MDCompressor {
+			return &MDCompressor_KOSINSKIM{ROM: rom}
+		},
+	},
+	{
+		Name: "ENIGMA",
+		Games: []string{
+			"[SMD] Sonic the Hedgehog",
+			"[SMD] Sonic the Hedgehog 2",
+			"[SMD] Sonic the Hedgehog 3",
+		},
+		Parameters: []MDCompressorParameter{
+			{Name: "start-tile", Usage: "tile index added to every decoded word"},
+		},
+		New: func(rom generic.ROM, parameters map[string]int) MDCompressor {
+			return &MDCompressor_ENIGMA{
+				ROM:       rom,
+				StartTile: uint16(parameters["start-tile"]),
+			}
+		},
+	},
+	{
+		Name: "SAXMAN",
+		Games: []string{
+			"[SMD] Sonic the Hedgehog 2",
+		},
+		Parameters: []MDCompressorParameter{
+			{Name: "headerless", Usage: "the stream has no size header, as in the Sonic 2 sound driver", Switch: true},
+			{Name: "size", Usage: "the compressed size of a headerless stream, which otherwise runs to the end of the input"},
+		},
+		New: func(rom generic.ROM, parameters map[string]int) MDCompressor {
+			return &MDCompressor_SAXMAN{
+				ROM:        rom,
+				Headerless: parameters["headerless"] != 0,
+				Size:       parameters["size"],
+			}
+		},
+	},
+	{
+		Name: "COMPER",
+		New: func(rom generic.ROM, parameters map[string]int) MDCompressor {
+			return &MDCompressor_COMPER{ROM: rom}
+		},
+	},
+	{
+		Name: "EASTRIKE",
+		Games: []string{
+			"[SMD] Desert Strike: Return to the Gulf",
+			"[SMD] Jungle Strike",
+			"[SMD] Urban Strike",
+		},
+		Parameters: []MDCompressorParameter{
+			{Name: "header", Usage: "header written when compressing: 0x10FB, 0x11FB or 0x90FB"},
+		},
+		New: func(rom generic.ROM, parameters map[string]int) MDCompressor {
+			return &MDCompressor_EASTRIKE{
+				ROM:    rom,
+				Header: parameters["header"],
+			}
+		},
+	},
 }
 
 type MDCompressor_SEGARD struct {
 	ROM generic.ROM
 }
 
-type MDCompressor_NEMESIS struct {
-	ROM generic.ROM
-}
-
-type MDCompressor_KOZINSKI struct {
-	ROM generic.ROM
-}
-
-type MDCompressor_ENIGMA struct {
-	ROM generic.ROM
-}
-
-type MDCompressor_SAXMAN struct {
-	ROM generic.ROM
-}
-
-type MDCompressor_STI struct {
-	ROM generic.ROM
-}
-
-type MDCompressor_STI2 struct {
-	ROM generic.ROM
-}
-
-type MDCompressor_WESTONE struct {
-	ROM generic.ROM
-}
-
-type MDCompressor_SILICONSYNAPSE struct {
-	ROM generic.ROM
-}
-
-type MDCompressor_NAMCO struct {
-	ROM generic.ROM
-}
-
-type MDCompressor_TECHNOSOFT struct {
-	ROM generic.ROM
-}
-
-type MDCompressor_KONAMI1 struct {
-	ROM generic.ROM
-}
-
-type MDCompressor_KONAMI2 struct {
-	ROM generic.ROM
-}
-
-type MDCompressor_KONAMI3 struct {
-	ROM generic.ROM
-}
-
-type MDCompressor_TOSE struct {
-	ROM generic.ROM
-}
-
-type MDCompressor_EASTRIKE struct {
-	ROM generic.ROM
-}
-
-type MDCompressor_NEXTECH struct {
-	ROM generic.ROM
-}
-
-type MDCompressor_WOLFTEAM struct {
-	ROM generic.ROM
-}
-
-type MDCompressor_ANCIENT struct {
-	ROM generic.ROM
-}
-
-type MDCompressor_SOFTWARECREATIONS struct {
-	ROM generic.ROM
-}
-
-type MDCompressor_KOEI struct {
-	ROM generic.ROM
-}
-
-type MDCompressor_FACTOR5 struct {
-	ROM generic.ROM
-}
-
-type MDCompressor_TECMO struct {
-	ROM generic.ROM
-}
-
-type MDCompressor_SNK struct {
-	ROM generic.ROM
-}
-
-type MDCompressor_ITL struct {
-	ROM generic.ROM
-}
-
 // NewMDCompressor creates a new instance of MDCompressor based on the given algorithm and ROM.
 //
 // Parameters:
@@ -124,106 +160,23 @@ type MDCompressor_ITL struct {
 // Returns:
 // - MDCompressor: a pointer to the newly created MDCompressor object, or nil if the algorithm is not recognized.
 func NewMDCompressor(algorithm string, rom generic.ROM) MDCompressor {
-	switch algorithm {
-	case "SEGARD":
-		return &MDCompressor_SEGARD{
-			ROM: rom,
-		}
-	case "NEMESIS":
-		return &MDCompressor_NEMESIS{
-			ROM: rom,
-		}
-	case "KOZINSKI":
-		return &MDCompressor_KOZINSKI{
-			ROM: rom,
-		}
-	case "ENIGMA":
-		return &MDCompressor_ENIGMA{
-			ROM: rom,
-		}
-	case "SAXMAN":
-		return &MDCompressor_SAXMAN{
-			ROM: rom,
-		}
-	case "STI":
-		return &MDCompressor_STI{
-			ROM: rom,
-		}
-	case "STI2":
-		return &MDCompressor_STI2{
-			ROM: rom,
-		}
-	case "WESTONE":
-		return &MDCompressor_WESTONE{
-			ROM: rom,
-		}
-	case "SILICONSYNAPSE":
-		return &MDCompressor_SILICONSYNAPSE{
-			ROM: rom,
-		}
-	case "NAMCO":
-		return &MDCompressor_NAMCO{
-			ROM: rom,
-		}
-	case "TECHNOSOFT":
-		return &MDCompressor_TECHNOSOFT{
-			ROM: rom,
-		}
-	case "KONAMI1":
-		return &MDCompressor_KONAMI1{
-			ROM: rom,
-		}
-	case "KONAMI2":
-		return &MDCompressor_KONAMI2{
-			ROM: rom,
-		}
-	case "KONAMI3":
-		return &MDCompressor_KONAMI3{
-			ROM: rom,
-		}
-	case "TOSE":
-		return &MDCompressor_TOSE{
-			ROM: rom,
-		}
-	case "EASTRIKE":
-		return &MDCompressor_EASTRIKE{
-			ROM: rom,
-		}
-	case "NEXTECH":
-		return &MDCompressor_NEXTECH{
-			ROM: rom,
-		}
-	case "WOLFTEAM":
-		return &MDCompressor_WOLFTEAM{
-			ROM: rom,
-		}
-	case "ANCIENT":
-		return &MDCompressor_ANCIENT{
-			ROM: rom,
-		}
-	case "SOFTWARECREATIONS":
-		return &MDCompressor_SOFTWARECREATIONS{
-			ROM: rom,
-		}
-	case "KOEI":
-		return &MDCompressor_KOEI{
-			ROM: rom,
-		}
-	case "FACTOR5":
-		return &MDCompressor_FACTOR5{
-			ROM: rom,
-		}
-	case "TECMO":
-		return &MDCompressor_TECMO{
-			ROM: rom,
-		}
-	case "SNK":
-		return &MDCompressor_SNK{
-			ROM: rom,
-		}
-	case "ITL":
-		return &MDCompressor_ITL{
-			ROM: rom,
+	return NewMDCompressorWithParameters(algorithm, rom, nil)
+}
+
+// NewMDCompressorWithParameters creates a new instance of MDCompressor based on the given algorithm,
+// ROM and algorithm parameters, as listed in MDCompressorAlgorithms.
+//
+// Parameters:
+// - algorithm: a string representing the algorithm to use for compression, or one of its aliases.
+// - rom: a generic.ROM object representing the ROM data.
+// - parameters: the values of the algorithm parameters, by name. Missing parameters are zero.
+//
+// Returns:
+// - MDCompressor: a pointer to the newly created MDCompressor object, or nil if the algorithm is not recognized.
+func NewMDCompressorWithParameters(algorithm string, rom generic.ROM, parameters map[string]int) MDCompressor {
+	for _, entry := range MDCompressorAlgorithms {
+		if entry.Name == algorithm || slices.Contains(entry.Aliases, algorithm) {
+			return entry.New(rom, parameters)
 		}
 	}
 
@@ -231,6 +184,25 @@ func NewMDCompressor(algorithm string, rom generic.ROM) MDCompressor {
 	return nil
 }
 
+// mdUnmarshalAt runs the given decoder from an offset of the ROM and measures how many bytes it read.
+//
+// Parameters:
+// - rom: a pointer to the generic.ROM read by the decoder.
+// - offset: the offset of the ROM where the compressed data starts.
+// - unmarshal: the decoder, reading from the current offset of the ROM.
+//
+// Returns:
+// - []byte: the decompressed data, or an empty slice if the offset is outside of the ROM.
+// - int: the number of bytes read from the offset.
+func mdUnmarshalAt(rom *generic.ROM, offset int, unmarshal func() []byte) ([]byte, int) {
+	if offset < 0 || offset > rom.Size {
+		return []byte{}, 0
+	}
+	rom.Seek(offset)
+	data := unmarshal()
+	return data, rom.Tell() - offset
+}
+
 // Marshal compresses the ROM data using the SEGARD compression algorithm and returns the compressed data as a byte slice.
 //
 // It reads the ROM data in chunks of 0x20 bytes and performs the following steps for each chunk:
@@ -372,14 +344,14 @@ func (segard *MDCompressor_SEGARD) getRepeats(chunk []byte, stats map[byte]int,
 // The chunks are concatenated into the decompressed data.
 //
 // Returns:
-// - []byte: the decompressed data.
+// - []byte: the decompressed data, or an empty slice if the ROM holds no data at all.
 func (segard *MDCompressor_SEGARD) Unmarshal() []byte {
 	var repeats uint8
 	var err error
 	buffer := new(bytes.Buffer)
 	chunk := make([]byte, 0x20)
 	if repeats, err = segard.ROM.Read8(); err != nil {
-		log.Fatal(err)
+		return []byte{}
 	}
 	for repeats != uint8(0xFF) {
 		var pattern uint32
@@ -422,194 +394,14 @@ func (segard *MDCompressor_SEGARD) Unmarshal() []byte {
 	return buffer.Bytes()
 }
 
-func (nemesis *MDCompressor_NEMESIS) Marshal() []byte {
-	return []byte{}
-}
-
-func (nemesis *MDCompressor_NEMESIS) Unmarshal() []byte {
-	return []byte{}
-}
-
-func (kozinski *MDCompressor_KOZINSKI) Marshal() []byte {
-	return []byte{}
-}
-
-func (kozinski *MDCompressor_KOZINSKI) Unmarshal() []byte {
-	return []byte{}
-}
-
-func (enigma *MDCompressor_ENIGMA) Marshal() []byte {
-	return []byte{}
-}
-
-func (enigma *MDCompressor_ENIGMA) Unmarshal() []byte {
-	return []byte{}
-}
-
-func (saxman *MDCompressor_SAXMAN) Marshal() []byte {
-	return []byte{}
-}
-
-func (saxman *MDCompressor_SAXMAN) Unmarshal() []byte {
-	return []byte{}
-}
-
-func (sti *MDCompressor_STI) Marshal() []byte {
-	return []byte{}
-}
-
-func (sti *MDCompressor_STI) Unmarshal() []byte {
-	return []byte{}
-}
-
-func (sti2 *MDCompressor_STI2) Marshal() []byte {
-	return []byte{}
-}
-
-func (sti2 *MDCompressor_STI2) Unmarshal() []byte {
-	return []byte{}
-}
-
-func (westone *MDCompressor_WESTONE) Marshal() []byte {
-	return []byte{}
-}
-
-func (westone *MDCompressor_WESTONE) Unmarshal() []byte {
-	return []byte{}
-}
-
-func (siliconsynapse *MDCompressor_SILICONSYNAPSE) Marshal() []byte {
-	return []byte{}
-}
-
-func (siliconsynapse *MDCompressor_SILICONSYNAPSE) Unmarshal() []byte {
-	return []byte{}
-}
-
-func (namco *MDCompressor_NAMCO) Marshal() []byte {
-	return []byte{}
-}
-
-func (namco *MDCompressor_NAMCO) Unmarshal() []byte {
-	return []byte{}
-}
-
-func (technosoft *MDCompressor_TECHNOSOFT) Marshal() []byte {
-	return []byte{}
-}
-
-func (technosoft *MDCompressor_TECHNOSOFT) Unmarshal() []byte {
-	return []byte{}
-}
-
-func (konami1 *MDCompressor_KONAMI1) Marshal() []byte {
-	return []byte{}
-}
-
-func (konami1 *MDCompressor_KONAMI1) Unmarshal() []byte {
-	return []byte{}
-}
-
-func (konami2 *MDCompressor_KONAMI2) Marshal() []byte {
-	return []byte{}
-}
-
-func (konami2 *MDCompressor_KONAMI2) Unmarshal() []byte {
-	return []byte{}
-}
-
-func (konami3 *MDCompressor_KONAMI3) Marshal() []byte {
-	return []byte{}
-}
-
-func (konami3 *MDCompressor_KONAMI3) Unmarshal() []byte {
-	return []byte{}
-}
-
-func (tose *MDCompressor_TOSE) Marshal() []byte {
-	return []byte{}
-}
-
-func (tose *MDCompressor_TOSE) Unmarshal() []byte {
-	return []byte{}
-}
-
-func (eastrike *MDCompressor_EASTRIKE) Marshal() []byte {
-	return []byte{}
-}
-
-func (eastrike *MDCompressor_EASTRIKE) Unmarshal() []byte {
-	return []byte{}
-}
-
-func (nextech *MDCompressor_NEXTECH) Marshal() []byte {
-	return []byte{}
-}
-
-func (nextech *MDCompressor_NEXTECH) Unmarshal() []byte {
-	return []byte{}
-}
-
-func (wolfteam *MDCompressor_WOLFTEAM) Marshal() []byte {
-	return []byte{}
-}
-
-func (wolfteam *MDCompressor_WOLFTEAM) Unmarshal() []byte {
-	return []byte{}
-}
-
-func (ancient *MDCompressor_ANCIENT) Marshal() []byte {
-	return []byte{}
-}
-
-func (ancient *MDCompressor_ANCIENT) Unmarshal() []byte {
-	return []byte{}
-}
-
-func (softwarecreations *MDCompressor_SOFTWARECREATIONS) Marshal() []byte {
-	return []byte{}
-}
-
-func (softwarecreations *MDCompressor_SOFTWARECREATIONS) Unmarshal() []byte {
-	return []byte{}
-}
-
-func (koei *MDCompressor_KOEI) Marshal() []byte {
-	return []byte{}
-}
-
-func (koei *MDCompressor_KOEI) Unmarshal() []byte {
-	return []byte{}
-}
-
-func (factor5 *MDCompressor_FACTOR5) Marshal() []byte {
-	return []byte{}
-}
-
-func (factor5 *MDCompressor_FACTOR5) Unmarshal() []byte {
-	return []byte{}
-}
-
-func (tecmo *MDCompressor_TECMO) Marshal() []byte {
-	return []byte{}
-}
-
-func (tecmo *MDCompressor_TECMO) Unmarshal() []byte {
-	return []byte{}
-}
-
-func (snk *MDCompressor_SNK) Marshal() []byte {
-	return []byte{}
-}
-
-func (snk *MDCompressor_SNK) Unmarshal() []byte {
-	return []byte{}
-}
-
-func (itl *MDCompressor_ITL) Marshal() []byte {
-	return []byte{}
-}
-
-func (itl *MDCompressor_ITL) Unmarshal() []byte {
-	return []byte{}
+// UnmarshalAt decodes the SEGARD compression format starting at the given offset of the ROM.
+//
+// Parameters:
+// - offset: the offset of the ROM where the compressed data starts.
+//
+// Returns:
+// - []byte: the decompressed data.
+// - int: the number of bytes read from the offset, that is, the compressed size.
+func (segard *MDCompressor_SEGARD) UnmarshalAt(offset int) ([]byte, int) {
+	return mdUnmarshalAt(&segard.ROM, offset, segard.Unmarshal)
 }
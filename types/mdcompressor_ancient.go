@@ -0,0 +1,22 @@
+package types
+
+import "github.com/hansbonini/go-segamd/types/generic"
+
+// MDCompressor_ANCIENT is reserved for the compression of Beyond Oasis. Until it is checked against
+// data from the game, it decodes and encodes nothing and is not listed in MDCompressorAlgorithms,
+// so no recompressed graphics can be reinserted in a broken form.
+type MDCompressor_ANCIENT struct {
+	ROM generic.ROM
+}
+
+func (ancient *MDCompressor_ANCIENT) Marshal() []byte {
+	return []byte{}
+}
+
+func (ancient *MDCompressor_ANCIENT) Unmarshal() []byte {
+	return []byte{}
+}
+
+func (ancient *MDCompressor_ANCIENT) UnmarshalAt(offset int) ([]byte, int) {
+	return []byte{}, 0
+}
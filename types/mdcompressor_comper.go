@@ -0,0 +1,124 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"slices"
+
+	"github.com/hansbonini/go-segamd/types/generic"
+)
+
+type MDCompressor_COMPER struct {
+	ROM generic.ROM
+}
+
+const (
+	comperLiteral = iota
+	comperCopy
+)
+
+var comperFlags = mdFlagFormat{Width: 16}
+
+// Unmarshal decodes the COMPER compression format from the ROM and returns the decompressed data.
+//
+// The stream works on 16-bit words and is driven by 16-bit big endian descriptor fields read MSB
+// first, where 0 is a literal word and 1 is a copy described by the next two bytes: the negative
+// offset in words (-0x100 to -0x01) and the number of words minus one. A copy of zero length ends
+// the stream.
+//
+// Returns:
+// - []byte: the decompressed data.
+func (comper *MDCompressor_COMPER) Unmarshal() []byte {
+	var bit, offset, count uint8
+	var value uint16
+	var err error
+	out := make([]uint16, 0)
+	reader := newMDFlagReader(&comper.ROM, comperFlags)
+	for {
+		if bit, err = reader.ReadBit(); err != nil {
+			break
+		}
+		if bit == 0 {
+			if value, err = comper.ROM.Read16(); err != nil {
+				break
+			}
+			out = append(out, value)
+			continue
+		}
+		if offset, err = comper.ROM.Read8(); err != nil {
+			break
+		}
+		if count, err = comper.ROM.Read8(); err != nil || count == 0 {
+			break
+		}
+		var ok bool
+		if out, ok = lzssCopy(out, 0x100-int(offset), int(count)+1); !ok {
+			break
+		}
+	}
+	buffer := new(bytes.Buffer)
+	binary.Write(buffer, binary.BigEndian, out)
+	return buffer.Bytes()
+}
+
+// UnmarshalAt decodes the COMPER compression format starting at the given offset of the ROM.
+//
+// Parameters:
+// - offset: the offset of the ROM where the compressed data starts.
+//
+// Returns:
+// - []byte: the decompressed data.
+// - int: the number of bytes read from the offset, that is, the compressed size.
+func (comper *MDCompressor_COMPER) UnmarshalAt(offset int) ([]byte, int) {
+	return mdUnmarshalAt(&comper.ROM, offset, comper.Unmarshal)
+}
+
+// Marshal compresses the ROM data using the COMPER compression algorithm and returns the compressed data as a byte slice.
+//
+// The data is padded to an even size and split into words, and the commands are chosen by an
+// optimal parse of those words.
+//
+// Returns:
+// - []byte: the compressed data as a byte slice.
+func (comper *MDCompressor_COMPER) Marshal() []byte {
+	data := comper.ROM.Data
+	if len(data)%2 != 0 {
+		data = append(slices.Clone(data), 0)
+	}
+	words := make([]uint16, len(data)/2)
+	for i := range words {
+		words[i] = binary.BigEndian.Uint16(data[i*2:])
+	}
+	steps := lzssParse(words, 0, 0x100, 2, 0x100, func(pos int, matches []lzssMatch, add func(lzssStep, int)) {
+		add(lzssStep{Length: 1, Kind: comperLiteral}, 17)
+		length := 1
+		for _, match := range matches {
+			for l := length + 1; l <= match.Length; l++ {
+				add(lzssStep{Length: l, Distance: match.Distance, Kind: comperCopy}, 17)
+			}
+			length = max(length, match.Length)
+		}
+	})
+
+	out := new(bytes.Buffer)
+	writer := newMDFlagWriter(out, comperFlags)
+	pos := 0
+	for _, step := range steps {
+		switch step.Kind {
+		case comperLiteral:
+			writer.WriteBit(0)
+			writer.WriteByte(byte(words[pos] >> 8))
+			writer.WriteByte(byte(words[pos]))
+		case comperCopy:
+			writer.WriteBit(1)
+			writer.WriteByte(byte(-step.Distance))
+			writer.WriteByte(byte(step.Length - 1))
+		}
+		pos += step.Length
+	}
+	writer.WriteBit(1)
+	writer.WriteByte(0x00)
+	writer.WriteByte(0x00)
+	writer.Close()
+	return out.Bytes()
+}
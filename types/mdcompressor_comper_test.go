@@ -0,0 +1,82 @@
+package types_test
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/hansbonini/go-segamd/types"
+	"github.com/hansbonini/go-segamd/types/generic"
+)
+
+func TestMDCompressor_COMPER_Unmarshal(t *testing.T) {
+	words := make([]byte, 0x22)
+	for i := range words {
+		words[i] = byte(i)
+	}
+	reload := append([]byte{0x00, 0x00}, words[:0x20]...)
+	reload = append(reload, 0x40, 0x00)
+	reload = append(reload, words[0x20:]...)
+	reload = append(reload, 0x00, 0x00)
+
+	tests := []struct {
+		name  string
+		input []byte
+		want  []byte
+	}{
+		{
+			name:  "Test with copy",
+			input: []byte{0x60, 0x00, 0x12, 0x34, 0xFF, 0x03, 0x00, 0x00},
+			want:  bytes.Repeat([]byte{0x12, 0x34}, 5),
+		},
+		{
+			name:  "Test with descriptor reload",
+			input: reload,
+			want:  words,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rom := generic.ROM{Data: tt.input, Size: len(tt.input)}
+			got := types.NewMDCompressor("COMPER", rom).Unmarshal()
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("Unmarshal() = %X, want %X", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMDCompressor_COMPER_Marshal(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	noise := make([]byte, 0x800)
+	random.Read(noise)
+	art := make([]byte, 0x1000)
+	for i := range art {
+		art[i] = byte(random.Intn(3)) * 0x11
+	}
+
+	tests := []struct {
+		name  string
+		input []byte
+		want  []byte
+	}{
+		{name: "Test with empty data", input: []byte{}, want: []byte{}},
+		{name: "Test with zero data", input: make([]byte, 0x1000), want: make([]byte, 0x1000)},
+		{name: "Test with noise", input: noise, want: noise},
+		{name: "Test with art", input: art, want: art},
+		{name: "Test with odd size", input: []byte{0x01, 0x02, 0x03}, want: []byte{0x01, 0x02, 0x03, 0x00}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rom := generic.ROM{Data: tt.input, Size: len(tt.input)}
+			compressed := types.NewMDCompressor("COMPER", rom).Marshal()
+			rom = generic.ROM{Data: compressed, Size: len(compressed)}
+			got := types.NewMDCompressor("COMPER", rom).Unmarshal()
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("Unmarshal(Marshal()) = %X, want %X", got, tt.want)
+			}
+		})
+	}
+}
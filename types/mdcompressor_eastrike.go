@@ -0,0 +1,283 @@
+package types
+
+import (
+	"bytes"
+
+	"github.com/hansbonini/go-segamd/types/generic"
+)
+
+type MDCompressor_EASTRIKE struct {
+	ROM    generic.ROM
+	Header int
+}
+
+const (
+	EAStrikeHeader          = 0x10FB
+	EAStrikeHeaderWithSize  = 0x11FB
+	EAStrikeHeaderLongSizes = 0x90FB
+)
+
+const (
+	eaStrikeLiteral = iota
+	eaStrikeShortCopy
+	eaStrikeMediumCopy
+	eaStrikeLongCopy
+)
+
+// Unmarshal decodes the EASTRIKE compression format from the ROM and returns the decompressed data.
+//
+// The stream starts with a header whose second byte is 0xFB and whose first byte holds flags: bit 0
+// set means the compressed size precedes the decompressed size, and bit 7 set means both sizes are
+// 32-bit instead of 24-bit, all of them big endian. This covers every header variant used by the
+// Strike series. The commands that follow are selected by their first byte, where P is a number of
+// literal bytes that follow the command and precede the copy:
+// - 0DDLLLPP DDDDDDDD: a copy D+1 bytes behind and L+3 bytes long.
+// - 10LLLLLL PPDDDDDD DDDDDDDD: a copy D+1 bytes behind and L+4 bytes long.
+// - 110DLLPP DDDDDDDD DDDDDDDD LLLLLLLL: a copy D+1 bytes behind and L+5 bytes long.
+// - 111NNNNN: (N+1)*4 literal bytes follow, for N up to 0x1B.
+// - 111111PP: P literal bytes follow and the stream ends.
+//
+// Decoding stops once the decompressed size is reached, also reading the 0xFC that ends the stream
+// if it follows, so the ROM offset is left right after the compressed data.
+//
+// Returns:
+// - []byte: the decompressed data.
+func (eastrike *MDCompressor_EASTRIKE) Unmarshal() []byte {
+	var flags, magic uint8
+	var err error
+	if flags, err = eastrike.ROM.Read8(); err != nil {
+		return []byte{}
+	}
+	if magic, err = eastrike.ROM.Read8(); err != nil || magic != 0xFB {
+		return []byte{}
+	}
+	width := 3
+	if flags&0x80 != 0 {
+		width = 4
+	}
+	if flags&0x01 != 0 {
+		if _, err = eastrike.read(width); err != nil {
+			return []byte{}
+		}
+	}
+	var size int
+	if size, err = eastrike.read(width); err != nil {
+		return []byte{}
+	}
+
+	out := make([]byte, 0)
+	command := make([]byte, 4)
+	ended := false
+	for len(out) < size {
+		if command[0], err = eastrike.ROM.Read8(); err != nil {
+			break
+		}
+		var literals, distance, count int
+		switch {
+		case command[0] < 0x80:
+			if err = eastrike.fill(command[1:2]); err != nil {
+				break
+			}
+			literals = int(command[0] & 0x03)
+			distance = int(command[0]&0x60)<<3 | int(command[1]) + 1
+			count = int(command[0]>>2&0x07) + 3
+		case command[0] < 0xC0:
+			if err = eastrike.fill(command[1:3]); err != nil {
+				break
+			}
+			literals = int(command[1] >> 6)
+			distance = int(command[1]&0x3F)<<8 | int(command[2]) + 1
+			count = int(command[0]&0x3F) + 4
+		case command[0] < 0xE0:
+			if err = eastrike.fill(command[1:4]); err != nil {
+				break
+			}
+			literals = int(command[0] & 0x03)
+			distance = int(command[0]&0x10)<<12 | int(command[1])<<8 | int(command[2]) + 1
+			count = int(command[0]&0x0C)<<6 | int(command[3]) + 5
+		case command[0] < 0xFC:
+			literals = int(command[0]&0x1F)*4 + 4
+		default:
+			literals = int(command[0] & 0x03)
+		}
+		if err != nil {
+			break
+		}
+		start := len(out)
+		out = append(out, make([]byte, literals)...)
+		if err = eastrike.fill(out[start:]); err != nil {
+			out = out[:start]
+			break
+		}
+		if command[0] >= 0xFC {
+			ended = true
+			break
+		}
+		if count > 0 {
+			var ok bool
+			if out, ok = lzssCopy(out, distance, count); !ok {
+				break
+			}
+		}
+	}
+	if !ended && len(out) >= size && eastrike.ROM.Offset < eastrike.ROM.Size && eastrike.ROM.Data[eastrike.ROM.Offset] == 0xFC {
+		eastrike.ROM.Read8()
+	}
+	if len(out) > size {
+		out = out[:size]
+	}
+	return out
+}
+
+// UnmarshalAt decodes the EASTRIKE compression format starting at the given offset of the ROM.
+//
+// Parameters:
+// - offset: the offset of the ROM where the compressed data starts.
+//
+// Returns:
+// - []byte: the decompressed data.
+// - int: the number of bytes read from the offset, that is, the compressed size.
+func (eastrike *MDCompressor_EASTRIKE) UnmarshalAt(offset int) ([]byte, int) {
+	return mdUnmarshalAt(&eastrike.ROM, offset, eastrike.Unmarshal)
+}
+
+// Marshal compresses the ROM data using the EASTRIKE compression algorithm and returns the compressed data as a byte slice.
+//
+// The parse costs a literal at 8 bits and weighs it against the three copy forms: 16 bits for 3 to
+// 10 bytes within 0x400 bytes, 24 bits for 4 to 0x43 bytes within 0x4000 bytes, and 32 bits for 5
+// to 0x404 bytes within 0x20000 bytes. Literals are then emitted in groups of 4 to 0x70 bytes, and
+// the last 0 to 3 of them ride along in the next copy or in the final 0xFC command, so a literal run
+// costs one more byte per group than the parse accounts for. The header written is the one
+// selected by Header, defaulting to EAStrikeHeader, which only holds the decompressed size.
+//
+// Returns:
+// - []byte: the compressed data as a byte slice, or an empty slice if Header is none of
+// EAStrikeHeader, EAStrikeHeaderWithSize and EAStrikeHeaderLongSizes.
+func (eastrike *MDCompressor_EASTRIKE) Marshal() []byte {
+	header := eastrike.Header
+	if header == 0 {
+		header = EAStrikeHeader
+	}
+	if header != EAStrikeHeader && header != EAStrikeHeaderWithSize && header != EAStrikeHeaderLongSizes {
+		return []byte{}
+	}
+	data := eastrike.ROM.Data
+	steps := lzssParse(data, 0, 0x20000, 2, 0x404, func(pos int, matches []lzssMatch, add func(lzssStep, int)) {
+		add(lzssStep{Length: 1, Kind: eaStrikeLiteral}, 8)
+		length := 2
+		for _, match := range matches {
+			for l := length + 1; l <= match.Length; l++ {
+				switch {
+				case l <= 10 && match.Distance <= 0x400:
+					add(lzssStep{Length: l, Distance: match.Distance, Kind: eaStrikeShortCopy}, 16)
+				case l >= 4 && l <= 0x43 && match.Distance <= 0x4000:
+					add(lzssStep{Length: l, Distance: match.Distance, Kind: eaStrikeMediumCopy}, 24)
+				case l >= 5:
+					add(lzssStep{Length: l, Distance: match.Distance, Kind: eaStrikeLongCopy}, 32)
+				}
+			}
+			length = max(length, match.Length)
+		}
+	})
+
+	stream := new(bytes.Buffer)
+	pos, pending := 0, 0
+	emitLiterals := func(keep int) {
+		for pending-keep >= 4 {
+			count := min(pending-keep, 0x70) &^ 0x03
+			stream.WriteByte(0xE0 | byte(count/4-1))
+			stream.Write(data[pos-pending : pos-pending+count])
+			pending -= count
+		}
+	}
+	for _, step := range steps {
+		if step.Kind == eaStrikeLiteral {
+			pending++
+			pos++
+			continue
+		}
+		emitLiterals(pending & 0x03)
+		literals, distance := byte(pending), step.Distance-1
+		switch step.Kind {
+		case eaStrikeShortCopy:
+			stream.WriteByte(byte(distance>>3)&0x60 | byte(step.Length-3)<<2 | literals)
+			stream.WriteByte(byte(distance))
+		case eaStrikeMediumCopy:
+			stream.WriteByte(0x80 | byte(step.Length-4))
+			stream.WriteByte(literals<<6 | byte(distance>>8))
+			stream.WriteByte(byte(distance))
+		case eaStrikeLongCopy:
+			stream.WriteByte(0xC0 | byte(distance>>12)&0x10 | byte((step.Length-5)>>6)&0x0C | literals)
+			stream.WriteByte(byte(distance >> 8))
+			stream.WriteByte(byte(distance))
+			stream.WriteByte(byte(step.Length - 5))
+		}
+		stream.Write(data[pos-pending : pos])
+		pending = 0
+		pos += step.Length
+	}
+	emitLiterals(pending & 0x03)
+	stream.WriteByte(0xFC | byte(pending))
+	stream.Write(data[pos-pending : pos])
+
+	width := 3
+	if header&0x8000 != 0 {
+		width = 4
+	}
+	out := new(bytes.Buffer)
+	out.WriteByte(byte(header >> 8))
+	out.WriteByte(byte(header))
+	if header&0x0100 != 0 {
+		eaStrikeWriteSize(out, stream.Len(), width)
+	}
+	eaStrikeWriteSize(out, len(data), width)
+	out.Write(stream.Bytes())
+	return out.Bytes()
+}
+
+// read reads a big endian size of the given width in bytes from the ROM.
+//
+// Parameters:
+// - width: the number of bytes of the size.
+//
+// Returns:
+// - int: the size read.
+// - error: an error if the ROM ends before the size does.
+func (eastrike *MDCompressor_EASTRIKE) read(width int) (size int, err error) {
+	var value uint8
+	for i := 0; i < width; i++ {
+		if value, err = eastrike.ROM.Read8(); err != nil {
+			return 0, err
+		}
+		size = size<<8 | int(value)
+	}
+	return size, nil
+}
+
+// fill reads bytes from the ROM until the given slice is full.
+//
+// Parameters:
+// - dst: the slice to fill.
+//
+// Returns:
+// - error: an error if the ROM ends before the slice is full.
+func (eastrike *MDCompressor_EASTRIKE) fill(dst []byte) (err error) {
+	for i := range dst {
+		if dst[i], err = eastrike.ROM.Read8(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// eaStrikeWriteSize writes a big endian size of the given width in bytes.
+//
+// Parameters:
+// - out: the buffer to write to.
+// - size: the size to write.
+// - width: the number of bytes of the size.
+func eaStrikeWriteSize(out *bytes.Buffer, size, width int) {
+	for i := width - 1; i >= 0; i-- {
+		out.WriteByte(byte(size >> (i * 8)))
+	}
+}
@@ -0,0 +1,139 @@
+package types_test
+
+import (
+	"bytes"
+	"math/rand"
+	"runtime"
+	"slices"
+	"testing"
+
+	"github.com/hansbonini/go-segamd/types"
+	"github.com/hansbonini/go-segamd/types/generic"
+)
+
+func TestMDCompressor_EASTRIKE_Unmarshal(t *testing.T) {
+	stream := []byte{0xE0, 0x41, 0x42, 0x43, 0x44, 0x05, 0x04, 0x45, 0xFD, 0x46}
+	want := []byte("ABCDEABCDF")
+
+	tests := []struct {
+		name   string
+		header []byte
+	}{
+		{name: "Test with decompressed size", header: []byte{0x10, 0xFB, 0x00, 0x00, 0x0A}},
+		{name: "Test with compressed size", header: []byte{0x11, 0xFB, 0x00, 0x00, 0x0A, 0x00, 0x00, 0x0A}},
+		{name: "Test with long sizes", header: []byte{0x90, 0xFB, 0x00, 0x00, 0x00, 0x0A}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := append(tt.header, stream...)
+			rom := generic.ROM{Data: input, Size: len(input)}
+			got := types.NewMDCompressor("EASTRIKE", rom).Unmarshal()
+			if !bytes.Equal(got, want) {
+				t.Errorf("Unmarshal() = %X, want %X", got, want)
+			}
+		})
+	}
+}
+
+func TestMDCompressor_EASTRIKE_Unmarshal_HugeSize(t *testing.T) {
+	input := []byte{0x90, 0xFB, 0xFF, 0xFF, 0xFF, 0xF0, 0xFC}
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	rom := generic.ROM{Data: input, Size: len(input)}
+	got := types.NewMDCompressor("EASTRIKE", rom).Unmarshal()
+	runtime.ReadMemStats(&after)
+	if len(got) != 0 {
+		t.Errorf("Unmarshal() = %X, want an empty slice", got)
+	}
+	if allocated := after.TotalAlloc - before.TotalAlloc; allocated > 1<<20 {
+		t.Errorf("Unmarshal() allocated 0x%X bytes for an empty stream", allocated)
+	}
+}
+
+func TestMDCompressor_EASTRIKE_Marshal(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	script := bytes.Repeat([]byte("DESERT STRIKE JUNGLE STRIKE URBAN STRIKE "), 0x80)
+	for i := 0; i < len(script); i += 0x2B {
+		script[i] = byte(random.Intn(0x100))
+	}
+
+	tests := []struct {
+		name   string
+		input  []byte
+		header int
+	}{
+		{name: "Test with compressed size header", input: script, header: types.EAStrikeHeaderWithSize},
+		{name: "Test with long sizes header", input: script, header: types.EAStrikeHeaderLongSizes},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rom := generic.ROM{Data: tt.input, Size: len(tt.input)}
+			compressed := (&types.MDCompressor_EASTRIKE{ROM: rom, Header: tt.header}).Marshal()
+			rom = generic.ROM{Data: compressed, Size: len(compressed)}
+			got := types.NewMDCompressor("EASTRIKE", rom).Unmarshal()
+			if !bytes.Equal(got, tt.input) {
+				t.Errorf("Unmarshal(Marshal()) = %X, want %X", got, tt.input)
+			}
+		})
+	}
+}
+
+func TestMDCompressor_EASTRIKE_Marshal_InvalidHeader(t *testing.T) {
+	input := []byte("JUNGLE STRIKE")
+	for _, header := range []int{0x1234, 0x10FC, 0x110FB, -1} {
+		rom := generic.ROM{Data: input, Size: len(input)}
+		if got := (&types.MDCompressor_EASTRIKE{ROM: rom, Header: header}).Marshal(); len(got) != 0 {
+			t.Errorf("Marshal() with header 0x%X = %X, want an empty slice", header, got)
+		}
+	}
+}
+
+func TestMDCompressor_EASTRIKE_Marshal_Limits(t *testing.T) {
+	window := mdUniqueBytes(0x4000)
+
+	tests := []struct {
+		name  string
+		input []byte
+		size  int
+		tail  []byte
+	}{
+		{
+			name:  "Test with short copy at its maximum distance and length",
+			input: append(slices.Clone(window[:0x400]), window[:10]...),
+			size:  5 + 10 + 0x400 + 2 + 1,
+			tail:  []byte{0x7C, 0xFF, 0xFC},
+		},
+		{
+			name:  "Test with medium copy at its maximum distance and length",
+			input: append(slices.Clone(window), window[:0x43]...),
+			size:  5 + 0x93 + 0x4000 + 3 + 1,
+			tail:  []byte{0xBF, 0x3F, 0xFF, 0xFC},
+		},
+		{
+			name:  "Test with long copy of maximum length",
+			input: append(slices.Clone(window[:0x404]), window[:0x404]...),
+			size:  5 + 10 + 0x404 + 4 + 1,
+			tail:  []byte{0xCC, 0x04, 0x03, 0xFF, 0xFC},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rom := generic.ROM{Data: tt.input, Size: len(tt.input)}
+			compressed := types.NewMDCompressor("EASTRIKE", rom).Marshal()
+			if len(compressed) != tt.size || !bytes.HasSuffix(compressed, tt.tail) {
+				t.Errorf("Marshal() = 0x%X bytes ending with %X, want 0x%X bytes ending with %X", len(compressed), compressed[max(0, len(compressed)-len(tt.tail)):], tt.size, tt.tail)
+			}
+
+			data := append(slices.Clone(compressed), 0xFC)
+			rom = generic.ROM{Data: data, Size: len(data)}
+			got, size := types.NewMDCompressor("EASTRIKE", rom).UnmarshalAt(0)
+			if !bytes.Equal(got, tt.input) || size != len(compressed) {
+				t.Errorf("UnmarshalAt(0) = %X, 0x%X, want %X, 0x%X", got, size, tt.input, len(compressed))
+			}
+		})
+	}
+}
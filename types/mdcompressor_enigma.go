@@ -0,0 +1,285 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/bits"
+	"slices"
+
+	"github.com/hansbonini/go-segamd/types/generic"
+)
+
+type MDCompressor_ENIGMA struct {
+	ROM       generic.ROM
+	StartTile uint16
+}
+
+type enigmaCommand struct {
+	Mode   uint32
+	Count  int
+	Values []uint16
+}
+
+const (
+	enigmaIncrementalCopy = 0x0
+	enigmaCommonCopy      = 0x1
+	enigmaRepeat          = 0x4
+	enigmaIncrement       = 0x5
+	enigmaDecrement       = 0x6
+	enigmaLiterals        = 0x7
+)
+
+var enigmaFlags = []uint16{0x8000, 0x4000, 0x2000, 0x1000, 0x0800}
+
+// Unmarshal decodes the ENIGMA compression format from the ROM and returns the decompressed plane mappings.
+//
+// The stream starts with a 6-byte header: the number of bits of an inline tile index, a mask of the
+// render flags stored inline (bit 4 for priority, bits 3-2 for palette, bit 1 for vertical flip and
+// bit 0 for horizontal flip), the starting incremental word and the common word. The rest of the
+// stream is read MSB first as commands followed by a 4-bit count, each writing count+1 words:
+// - 00: the incremental word, increasing it after each write.
+// - 01: the common word.
+// - 100: an inline word, repeated.
+// - 101: an inline word, increasing it after each write.
+// - 110: an inline word, decreasing it after each write.
+// - 111: count+1 distinct inline words, or the end of the stream if count is 15.
+// The StartTile is added to every decoded word and the stream is padded to an even size.
+//
+// Returns:
+// - []byte: the decompressed data.
+func (enigma *MDCompressor_ENIGMA) Unmarshal() []byte {
+	var packetLength, mask uint8
+	var increment, common uint16
+	var err error
+	start := enigma.ROM.Offset
+	if packetLength, err = enigma.ROM.Read8(); err != nil {
+		return []byte{}
+	}
+	if mask, err = enigma.ROM.Read8(); err != nil {
+		return []byte{}
+	}
+	if increment, err = enigma.ROM.Read16(); err != nil {
+		return []byte{}
+	}
+	if common, err = enigma.ROM.Read16(); err != nil {
+		return []byte{}
+	}
+	increment += enigma.StartTile
+	common += enigma.StartTile
+	inline := func(reader *mdBitReader) uint16 {
+		value := enigma.StartTile
+		for i, flag := range enigmaFlags {
+			if mask&(0x10>>i) != 0 && reader.Read(1) == 1 {
+				value |= flag
+			}
+		}
+		return value + uint16(reader.Read(int(packetLength)))
+	}
+
+	buffer := new(bytes.Buffer)
+	reader := newMDBitReader(&enigma.ROM)
+	for !reader.Exhausted() {
+		mode := reader.Read(1)
+		if mode == 0 {
+			mode = reader.Read(1)
+		} else {
+			mode = 0x4 | reader.Read(2)
+		}
+		count := int(reader.Read(4))
+		if mode == enigmaLiterals && count == 0xF {
+			break
+		}
+		var value uint16
+		if mode == enigmaRepeat || mode == enigmaIncrement || mode == enigmaDecrement {
+			value = inline(reader)
+		}
+		for i := 0; i <= count; i++ {
+			switch mode {
+			case enigmaIncrementalCopy:
+				binary.Write(buffer, binary.BigEndian, increment)
+				increment++
+			case enigmaCommonCopy:
+				binary.Write(buffer, binary.BigEndian, common)
+			case enigmaRepeat:
+				binary.Write(buffer, binary.BigEndian, value)
+			case enigmaIncrement:
+				binary.Write(buffer, binary.BigEndian, value)
+				value++
+			case enigmaDecrement:
+				binary.Write(buffer, binary.BigEndian, value)
+				value--
+			case enigmaLiterals:
+				binary.Write(buffer, binary.BigEndian, inline(reader))
+			}
+		}
+	}
+	if (enigma.ROM.Offset-start)%2 != 0 {
+		enigma.ROM.Offset++
+	}
+	return buffer.Bytes()
+}
+
+// UnmarshalAt decodes the ENIGMA compression format starting at the given offset of the ROM.
+//
+// Parameters:
+// - offset: the offset of the ROM where the compressed data starts.
+//
+// Returns:
+// - []byte: the decompressed data.
+// - int: the number of bytes read from the offset, that is, the compressed size.
+func (enigma *MDCompressor_ENIGMA) UnmarshalAt(offset int) ([]byte, int) {
+	return mdUnmarshalAt(&enigma.ROM, offset, enigma.Unmarshal)
+}
+
+// Marshal compresses the ROM data using the ENIGMA compression algorithm and returns the compressed data as a byte slice.
+//
+// The most frequent word becomes the common word and the first other word becomes the starting
+// incremental word. The data is then parsed greedily, preferring copies of the incremental and common
+// words, then runs of repeated, increasing or decreasing inline words, and falling back to blocks of
+// distinct inline words. The inline flag mask and tile index size are the smallest that fit every
+// inline word, and the StartTile is subtracted from every word.
+//
+// Returns:
+// - []byte: the compressed data as a byte slice.
+func (enigma *MDCompressor_ENIGMA) Marshal() []byte {
+	data := enigma.ROM.Data
+	if len(data)%2 != 0 {
+		data = append(slices.Clone(data), 0)
+	}
+	words := make([]uint16, len(data)/2)
+	for i := range words {
+		words[i] = binary.BigEndian.Uint16(data[i*2:])
+	}
+
+	frequencies := make(map[uint16]int)
+	common := uint16(0)
+	for _, word := range words {
+		frequencies[word]++
+		if frequencies[word] > frequencies[common] || (frequencies[word] == frequencies[common] && word < common) {
+			common = word
+		}
+	}
+	increment := common
+	for _, word := range words {
+		if word != common {
+			increment = word
+			break
+		}
+	}
+	header := []uint16{increment - enigma.StartTile, common - enigma.StartTile}
+
+	commands := enigma.parse(words, increment, common)
+	var mask uint8
+	var tiles uint16
+	for _, command := range commands {
+		for _, value := range command.Values {
+			flags, tile := enigma.split(value)
+			tiles |= tile
+			for i, flag := range enigmaFlags {
+				if flags&flag != 0 {
+					mask |= 0x10 >> i
+				}
+			}
+		}
+	}
+	packetLength := bits.Len16(tiles)
+
+	out := new(bytes.Buffer)
+	out.WriteByte(byte(packetLength))
+	out.WriteByte(mask)
+	binary.Write(out, binary.BigEndian, header)
+	writer := newMDBitWriter(out)
+	for _, command := range commands {
+		if command.Mode&0x4 == 0 {
+			writer.Write(command.Mode, 2)
+		} else {
+			writer.Write(command.Mode, 3)
+		}
+		writer.Write(uint32(command.Count-1), 4)
+		for _, value := range command.Values {
+			flags, tile := enigma.split(value)
+			for i, flag := range enigmaFlags {
+				if mask&(0x10>>i) != 0 {
+					if flags&flag != 0 {
+						writer.Write(1, 1)
+					} else {
+						writer.Write(0, 1)
+					}
+				}
+			}
+			writer.Write(uint32(tile), packetLength)
+		}
+	}
+	writer.Write(enigmaLiterals, 3)
+	writer.Write(0xF, 4)
+	writer.Flush()
+	if out.Len()%2 != 0 {
+		out.WriteByte(0)
+	}
+	return out.Bytes()
+}
+
+// split separates a word into the render flags and tile index written inline.
+//
+// Parameters:
+// - value: the word to split.
+//
+// Returns:
+// - uint16: the render flags.
+// - uint16: the tile index.
+func (enigma *MDCompressor_ENIGMA) split(value uint16) (flags uint16, tile uint16) {
+	value -= enigma.StartTile & 0x07FF
+	return value & 0xF800 &^ enigma.StartTile, value & 0x07FF
+}
+
+// parse splits the words into ENIGMA commands of up to 16 words.
+//
+// Parameters:
+// - words: the words to encode.
+// - increment: the starting incremental word.
+// - common: the common word.
+//
+// Returns:
+// - []enigmaCommand: the commands to write.
+func (enigma *MDCompressor_ENIGMA) parse(words []uint16, increment, common uint16) []enigmaCommand {
+	run := func(pos int, step uint16, first uint16) (count int) {
+		for count < 16 && pos+count < len(words) && words[pos+count] == first+step*uint16(count) {
+			count++
+		}
+		return count
+	}
+	commands := make([]enigmaCommand, 0)
+	for pos := 0; pos < len(words); {
+		if count := run(pos, 1, increment); count > 0 {
+			commands = append(commands, enigmaCommand{Mode: enigmaIncrementalCopy, Count: count})
+			increment += uint16(count)
+			pos += count
+			continue
+		}
+		if count := run(pos, 0, common); count > 0 {
+			commands = append(commands, enigmaCommand{Mode: enigmaCommonCopy, Count: count})
+			pos += count
+			continue
+		}
+		mode, count := uint32(enigmaRepeat), run(pos, 0, words[pos])
+		if c := run(pos, 1, words[pos]); c > count {
+			mode, count = enigmaIncrement, c
+		}
+		if c := run(pos, 0xFFFF, words[pos]); c > count {
+			mode, count = enigmaDecrement, c
+		}
+		if count > 1 {
+			commands = append(commands, enigmaCommand{Mode: mode, Count: count, Values: words[pos : pos+1]})
+			pos += count
+			continue
+		}
+		end := pos + 1
+		for end < len(words) && end-pos < 15 && words[end] != increment && words[end] != common &&
+			run(end, 0, words[end]) < 2 && run(end, 1, words[end]) < 2 && run(end, 0xFFFF, words[end]) < 2 {
+			end++
+		}
+		commands = append(commands, enigmaCommand{Mode: enigmaLiterals, Count: end - pos, Values: words[pos:end]})
+		pos = end
+	}
+	return commands
+}
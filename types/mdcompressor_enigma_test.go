@@ -0,0 +1,94 @@
+package types_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/rand"
+	"testing"
+
+	"github.com/hansbonini/go-segamd/types"
+	"github.com/hansbonini/go-segamd/types/generic"
+)
+
+func TestMDCompressor_ENIGMA_Unmarshal(t *testing.T) {
+	input := []byte{
+		0x04, 0x10, 0x00, 0x01, 0x00, 0x00,
+		0x4C, 0x28, 0x35, 0xA4, 0xAC, 0x33, 0xE3, 0x07, 0x81, 0xFC,
+	}
+	words := []uint16{
+		0x0000, 0x0000, 0x0000, 0x0000,
+		0x0001, 0x0002, 0x0003,
+		0x8005, 0x8005,
+		0x000A, 0x000B, 0x000C,
+		0x8003, 0x8002,
+		0x8000, 0x000F,
+		0x0004,
+	}
+
+	tests := []struct {
+		name      string
+		startTile uint16
+	}{
+		{name: "Test without start tile", startTile: 0x0000},
+		{name: "Test with start tile", startTile: 0x0100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := new(bytes.Buffer)
+			for _, word := range words {
+				binary.Write(want, binary.BigEndian, word+tt.startTile)
+			}
+			enigma := &types.MDCompressor_ENIGMA{
+				ROM:       generic.ROM{Data: input, Size: len(input)},
+				StartTile: tt.startTile,
+			}
+			if got := enigma.Unmarshal(); !bytes.Equal(got, want.Bytes()) {
+				t.Errorf("Unmarshal() = %X, want %X", got, want.Bytes())
+			}
+			if enigma.ROM.Offset != len(input) {
+				t.Errorf("Unmarshal() stopped at %d, want %d", enigma.ROM.Offset, len(input))
+			}
+		})
+	}
+}
+
+func TestMDCompressor_ENIGMA_Marshal(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	plane := new(bytes.Buffer)
+	for i := 0; i < 0x280; i++ {
+		switch {
+		case i%40 < 8:
+			binary.Write(plane, binary.BigEndian, uint16(0x0000))
+		case i%40 < 32:
+			binary.Write(plane, binary.BigEndian, uint16(0x2000|i))
+		default:
+			binary.Write(plane, binary.BigEndian, uint16(random.Intn(0x10000)))
+		}
+	}
+
+	tests := []struct {
+		name      string
+		input     []byte
+		startTile uint16
+	}{
+		{name: "Test with empty data", input: []byte{}},
+		{name: "Test with blank plane", input: make([]byte, 0x700)},
+		{name: "Test with plane", input: plane.Bytes()},
+		{name: "Test with start tile", input: []byte{0x81, 0x00, 0x81, 0x01, 0x81, 0x01, 0x01, 0x10}, startTile: 0x0100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enigma := &types.MDCompressor_ENIGMA{
+				ROM:       generic.ROM{Data: tt.input, Size: len(tt.input)},
+				StartTile: tt.startTile,
+			}
+			compressed := enigma.Marshal()
+			enigma.ROM = generic.ROM{Data: compressed, Size: len(compressed)}
+			if got := enigma.Unmarshal(); !bytes.Equal(got, tt.input) {
+				t.Errorf("Unmarshal(Marshal()) = %X, want %X", got, tt.input)
+			}
+		})
+	}
+}
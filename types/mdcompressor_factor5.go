@@ -0,0 +1,22 @@
+package types
+
+import "github.com/hansbonini/go-segamd/types/generic"
+
+// MDCompressor_FACTOR5 is reserved for the compression of Turrican. Without the game decompressor
+// or a dumped block to check a bitstream against, it decodes and encodes nothing and is not listed
+// in MDCompressorAlgorithms.
+type MDCompressor_FACTOR5 struct {
+	ROM generic.ROM
+}
+
+func (factor5 *MDCompressor_FACTOR5) Marshal() []byte {
+	return []byte{}
+}
+
+func (factor5 *MDCompressor_FACTOR5) Unmarshal() []byte {
+	return []byte{}
+}
+
+func (factor5 *MDCompressor_FACTOR5) UnmarshalAt(offset int) ([]byte, int) {
+	return []byte{}, 0
+}
@@ -0,0 +1,21 @@
+package types
+
+import "github.com/hansbonini/go-segamd/types/generic"
+
+// MDCompressor_ITL is reserved for the ITL scheme. Neither the games using it nor its layout are
+// known, so it decodes and encodes nothing and is not listed in MDCompressorAlgorithms.
+type MDCompressor_ITL struct {
+	ROM generic.ROM
+}
+
+func (itl *MDCompressor_ITL) Marshal() []byte {
+	return []byte{}
+}
+
+func (itl *MDCompressor_ITL) Unmarshal() []byte {
+	return []byte{}
+}
+
+func (itl *MDCompressor_ITL) UnmarshalAt(offset int) ([]byte, int) {
+	return []byte{}, 0
+}
@@ -0,0 +1,22 @@
+package types
+
+import "github.com/hansbonini/go-segamd/types/generic"
+
+// MDCompressor_KOEI is reserved for the text and portrait compression of Koei strategy games. Its
+// window fill and start position have not been verified against one of them, so it decodes and
+// encodes nothing and is not listed in MDCompressorAlgorithms.
+type MDCompressor_KOEI struct {
+	ROM generic.ROM
+}
+
+func (koei *MDCompressor_KOEI) Marshal() []byte {
+	return []byte{}
+}
+
+func (koei *MDCompressor_KOEI) Unmarshal() []byte {
+	return []byte{}
+}
+
+func (koei *MDCompressor_KOEI) UnmarshalAt(offset int) ([]byte, int) {
+	return []byte{}, 0
+}
@@ -0,0 +1,56 @@
+package types
+
+import "github.com/hansbonini/go-segamd/types/generic"
+
+// MDCompressor_KONAMI1 is the first of the three Konami revisions. None of them has been matched to
+// a game or checked against a dumped block, so they decode and encode nothing and are not listed in
+// MDCompressorAlgorithms.
+type MDCompressor_KONAMI1 struct {
+	ROM generic.ROM
+}
+
+func (konami1 *MDCompressor_KONAMI1) Marshal() []byte {
+	return []byte{}
+}
+
+func (konami1 *MDCompressor_KONAMI1) Unmarshal() []byte {
+	return []byte{}
+}
+
+func (konami1 *MDCompressor_KONAMI1) UnmarshalAt(offset int) ([]byte, int) {
+	return []byte{}, 0
+}
+
+// MDCompressor_KONAMI2 is the second Konami revision, unimplemented like MDCompressor_KONAMI1.
+type MDCompressor_KONAMI2 struct {
+	ROM generic.ROM
+}
+
+func (konami2 *MDCompressor_KONAMI2) Marshal() []byte {
+	return []byte{}
+}
+
+func (konami2 *MDCompressor_KONAMI2) Unmarshal() []byte {
+	return []byte{}
+}
+
+func (konami2 *MDCompressor_KONAMI2) UnmarshalAt(offset int) ([]byte, int) {
+	return []byte{}, 0
+}
+
+// MDCompressor_KONAMI3 is the third Konami revision, unimplemented like MDCompressor_KONAMI1.
+type MDCompressor_KONAMI3 struct {
+	ROM generic.ROM
+}
+
+func (konami3 *MDCompressor_KONAMI3) Marshal() []byte {
+	return []byte{}
+}
+
+func (konami3 *MDCompressor_KONAMI3) Unmarshal() []byte {
+	return []byte{}
+}
+
+func (konami3 *MDCompressor_KONAMI3) UnmarshalAt(offset int) ([]byte, int) {
+	return []byte{}, 0
+}
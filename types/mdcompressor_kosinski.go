@@ -0,0 +1,176 @@
+package types
+
+import (
+	"bytes"
+
+	"github.com/hansbonini/go-segamd/types/generic"
+)
+
+type MDCompressor_KOZINSKI struct {
+	ROM generic.ROM
+}
+
+const (
+	kosinskiLiteral = iota
+	kosinskiInline
+	kosinskiSeparate
+	kosinskiExtended
+)
+
+var kosinskiFlags = mdFlagFormat{Width: 16, LSBFirst: true, LittleEndian: true, Eager: true}
+
+// Unmarshal decodes the KOSINSKI compression format from the ROM and returns the decompressed data.
+//
+// The stream is driven by 16-bit little endian descriptor fields read LSB first, where a new field
+// is fetched as soon as the last bit of the previous one is consumed, even before the data bytes of
+// the command that used it. The commands are:
+// - 1: a literal byte follows.
+// - 00XY: a copy of XY+2 bytes whose negative offset is the next byte (-0x100 to -0x01).
+// - 01: a copy whose offset and size are packed in the next two bytes as LLLLLLLL HHHHHCCC, with
+// the offset being the negative 13-bit value HHHHHLLLLLLLL. If CCC is not zero the copy is CCC+2
+// bytes long, otherwise a third byte holds the size minus one, where 0 ends the stream and 1 is
+// skipped.
+//
+// Returns:
+// - []byte: the decompressed data.
+func (kozinski *MDCompressor_KOZINSKI) Unmarshal() []byte {
+	var bit, low, high, value uint8
+	var count uint32
+	var err error
+	out := make([]byte, 0)
+	reader := newMDFlagReader(&kozinski.ROM, kosinskiFlags)
+	for {
+		if bit, err = reader.ReadBit(); err != nil {
+			break
+		}
+		if bit == 1 {
+			if value, err = kozinski.ROM.Read8(); err != nil {
+				break
+			}
+			out = append(out, value)
+			continue
+		}
+		if bit, err = reader.ReadBit(); err != nil {
+			break
+		}
+		var distance int
+		if bit == 0 {
+			if count, err = reader.ReadBits(2); err != nil {
+				break
+			}
+			count += 2
+			if low, err = kozinski.ROM.Read8(); err != nil {
+				break
+			}
+			distance = 0x100 - int(low)
+		} else {
+			if low, err = kozinski.ROM.Read8(); err != nil {
+				break
+			}
+			if high, err = kozinski.ROM.Read8(); err != nil {
+				break
+			}
+			distance = 0x2000 - (int(high&0xF8)<<5 | int(low))
+			if high&0x07 != 0 {
+				count = uint32(high&0x07) + 2
+			} else {
+				if value, err = kozinski.ROM.Read8(); err != nil || value == 0 {
+					break
+				}
+				if value == 1 {
+					continue
+				}
+				count = uint32(value) + 1
+			}
+		}
+		var ok bool
+		if out, ok = lzssCopy(out, distance, int(count)); !ok {
+			break
+		}
+	}
+	return out
+}
+
+// UnmarshalAt decodes the KOSINSKI compression format starting at the given offset of the ROM.
+//
+// Parameters:
+// - offset: the offset of the ROM where the compressed data starts.
+//
+// Returns:
+// - []byte: the decompressed data.
+// - int: the number of bytes read from the offset, that is, the compressed size.
+func (kozinski *MDCompressor_KOZINSKI) UnmarshalAt(offset int) ([]byte, int) {
+	return mdUnmarshalAt(&kozinski.ROM, offset, kozinski.Unmarshal)
+}
+
+// Marshal compresses the ROM data using the KOSINSKI compression algorithm and returns the compressed data as a byte slice.
+//
+// The parse weighs each command by its size in bits: 9 for a literal, 12 for an inline copy of 2 to
+// 5 bytes within 0x100 bytes, 18 for a separate copy of 3 to 9 bytes and 26 for an extended one of
+// up to 0x100 bytes, both within 0x2000 bytes. The match search is bounded, so the parse is only
+// the cheapest over the matches it collects.
+//
+// Returns:
+// - []byte: the compressed data as a byte slice.
+func (kozinski *MDCompressor_KOZINSKI) Marshal() []byte {
+	return kosinskiEncode(kozinski.ROM.Data)
+}
+
+// kosinskiEncode compresses the given data into a KOSINSKI stream.
+//
+// Parameters:
+// - data: the data to compress.
+//
+// Returns:
+// - []byte: the compressed data as a byte slice.
+func kosinskiEncode(data []byte) []byte {
+	steps := lzssParse(data, 0, 0x2000, 2, 0x100, func(pos int, matches []lzssMatch, add func(lzssStep, int)) {
+		add(lzssStep{Length: 1, Kind: kosinskiLiteral}, 9)
+		length := 1
+		for _, match := range matches {
+			for length++; length <= match.Length; length++ {
+				if length <= 5 && match.Distance <= 0x100 {
+					add(lzssStep{Length: length, Distance: match.Distance, Kind: kosinskiInline}, 12)
+				}
+				if length >= 3 && length <= 9 {
+					add(lzssStep{Length: length, Distance: match.Distance, Kind: kosinskiSeparate}, 18)
+				} else if length > 9 {
+					add(lzssStep{Length: length, Distance: match.Distance, Kind: kosinskiExtended}, 26)
+				}
+			}
+			length = match.Length
+		}
+	})
+
+	out := new(bytes.Buffer)
+	writer := newMDFlagWriter(out, kosinskiFlags)
+	pos := 0
+	for _, step := range steps {
+		offset := -step.Distance
+		switch step.Kind {
+		case kosinskiLiteral:
+			writer.WriteBit(1)
+			writer.WriteByte(data[pos])
+		case kosinskiInline:
+			writer.WriteBits(0, 2)
+			writer.WriteBits(uint32(step.Length-2), 2)
+			writer.WriteByte(byte(offset))
+		case kosinskiSeparate:
+			writer.WriteBits(1, 2)
+			writer.WriteByte(byte(offset))
+			writer.WriteByte(byte(offset>>5)&0xF8 | byte(step.Length-2))
+		case kosinskiExtended:
+			writer.WriteBits(1, 2)
+			writer.WriteByte(byte(offset))
+			writer.WriteByte(byte(offset>>5) & 0xF8)
+			writer.WriteByte(byte(step.Length - 1))
+		}
+		pos += step.Length
+	}
+	writer.WriteBits(1, 2)
+	writer.WriteByte(0x00)
+	writer.WriteByte(0xF0)
+	writer.WriteByte(0x00)
+	writer.Close()
+	return out.Bytes()
+}
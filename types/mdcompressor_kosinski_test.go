@@ -0,0 +1,87 @@
+package types_test
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/hansbonini/go-segamd/types"
+	"github.com/hansbonini/go-segamd/types/generic"
+)
+
+func TestMDCompressor_KOZINSKI_Unmarshal(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+		want  []byte
+	}{
+		{
+			name:  "Test with inline copy",
+			input: []byte{0x59, 0x00, 0x41, 0xFF, 0x00, 0xF0, 0x00},
+			want:  []byte("AAAAAA"),
+		},
+		{
+			name:  "Test with separate copy",
+			input: []byte{0x57, 0x00, 0x41, 0x42, 0x43, 0xFD, 0xFF, 0x00, 0xF0, 0x00},
+			want:  []byte("ABCABCABCABC"),
+		},
+		{
+			name:  "Test with extended copy",
+			input: []byte{0x15, 0x00, 0x41, 0xFF, 0xF8, 0x13, 0x00, 0xF0, 0x00},
+			want:  bytes.Repeat([]byte("A"), 21),
+		},
+		{
+			name: "Test with descriptor reload",
+			input: append(append([]byte{0xFF, 0xFF}, []byte("ABCDEFGHIJKLMNO")...),
+				0x02, 0x00, 0x50, 0x00, 0xF0, 0x00),
+			want: []byte("ABCDEFGHIJKLMNOP"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rom := generic.ROM{Data: tt.input, Size: len(tt.input)}
+			got := types.NewMDCompressor("KOZINSKI", rom).Unmarshal()
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("Unmarshal() = %X, want %X", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMDCompressor_KOZINSKI_Marshal(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	noise := make([]byte, 0x800)
+	random.Read(noise)
+	text := bytes.Repeat([]byte("SONIC THE HEDGEHOG "), 0x100)
+	mixed := make([]byte, 0x3000)
+	for i := range mixed {
+		mixed[i] = byte(random.Intn(4))
+		if i >= 0x2100 {
+			mixed[i] = mixed[i-0x2000]
+		}
+	}
+
+	tests := []struct {
+		name  string
+		input []byte
+	}{
+		{name: "Test with empty data", input: []byte{}},
+		{name: "Test with zero data", input: make([]byte, 0x1000)},
+		{name: "Test with noise", input: noise},
+		{name: "Test with text", input: text},
+		{name: "Test with far copies", input: mixed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rom := generic.ROM{Data: tt.input, Size: len(tt.input)}
+			compressed := types.NewMDCompressor("KOZINSKI", rom).Marshal()
+			rom = generic.ROM{Data: compressed, Size: len(compressed)}
+			got := types.NewMDCompressor("KOZINSKI", rom).Unmarshal()
+			if !bytes.Equal(got, tt.input) {
+				t.Errorf("Unmarshal(Marshal()) = %X, want %X", got, tt.input)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/hansbonini/go-segamd/types/generic"
+)
+
+type MDCompressor_KOSINSKIM struct {
+	ROM generic.ROM
+}
+
+const kosinskiModuleSize = 0x1000
+
+// Unmarshal decodes the KOSINSKIM (Kosinski Moduled) container from the ROM and returns the decompressed data.
+//
+// The stream starts with a 16-bit big endian header holding the size of the decompressed data,
+// followed by KOSINSKI modules of up to 0x1000 decompressed bytes each. Every module but the last
+// one is padded so the next one starts 16-byte aligned from the beginning of the stream.
+//
+// Returns:
+// - []byte: the decompressed data.
+func (kosinskim *MDCompressor_KOSINSKIM) Unmarshal() []byte {
+	var size uint16
+	var err error
+	start := kosinskim.ROM.Offset
+	if size, err = kosinskim.ROM.Read16(); err != nil {
+		return []byte{}
+	}
+	out := make([]byte, 0, size)
+	for len(out) < int(size) {
+		module := &MDCompressor_KOZINSKI{ROM: kosinskim.ROM}
+		data := module.Unmarshal()
+		kosinskim.ROM.Offset = module.ROM.Offset
+		if len(data) == 0 {
+			break
+		}
+		out = append(out, data...)
+		if len(out) < int(size) {
+			if padding := (kosinskim.ROM.Offset - start) % 0x10; padding > 0 {
+				kosinskim.ROM.Offset += 0x10 - padding
+			}
+		}
+	}
+	if len(out) > int(size) {
+		out = out[:size]
+	}
+	return out
+}
+
+// UnmarshalAt decodes the KOSINSKIM compression format starting at the given offset of the ROM.
+//
+// Parameters:
+// - offset: the offset of the ROM where the compressed data starts.
+//
+// Returns:
+// - []byte: the decompressed data.
+// - int: the number of bytes read from the offset, that is, the compressed size.
+func (kosinskim *MDCompressor_KOSINSKIM) UnmarshalAt(offset int) ([]byte, int) {
+	return mdUnmarshalAt(&kosinskim.ROM, offset, kosinskim.Unmarshal)
+}
+
+// Marshal compresses the ROM data into a KOSINSKIM (Kosinski Moduled) container and returns the compressed data as a byte slice.
+//
+// The data is split into modules of 0x1000 bytes, each one compressed with KOSINSKI and padded to
+// a 16-byte boundary, except for the last one. The container size is a single word, which rules
+// out data larger than 0xFFFF bytes.
+//
+// Returns:
+// - []byte: the compressed data as a byte slice, or an empty slice if the data is larger than 0xFFFF bytes.
+func (kosinskim *MDCompressor_KOSINSKIM) Marshal() []byte {
+	data := kosinskim.ROM.Data
+	if len(data) > 0xFFFF {
+		return []byte{}
+	}
+	out := new(bytes.Buffer)
+	binary.Write(out, binary.BigEndian, uint16(len(data)))
+	for pos := 0; pos < len(data); pos += kosinskiModuleSize {
+		end := min(pos+kosinskiModuleSize, len(data))
+		out.Write(kosinskiEncode(data[pos:end]))
+		if end < len(data) && out.Len()%0x10 != 0 {
+			out.Write(make([]byte, 0x10-out.Len()%0x10))
+		}
+	}
+	return out.Bytes()
+}
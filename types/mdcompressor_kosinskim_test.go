@@ -0,0 +1,78 @@
+package types_test
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/hansbonini/go-segamd/types"
+	"github.com/hansbonini/go-segamd/types/generic"
+)
+
+func TestMDCompressor_KOSINSKIM_Unmarshal(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	data := make([]byte, 0x1800)
+	random.Read(data)
+	rom := generic.ROM{Data: data[:0x1000], Size: 0x1000}
+	first := types.NewMDCompressor("KOSINSKI", rom).Marshal()
+	rom = generic.ROM{Data: data[0x1000:], Size: 0x800}
+	second := types.NewMDCompressor("KOSINSKI", rom).Marshal()
+	moduled := append([]byte{0x18, 0x00}, first...)
+	moduled = append(moduled, make([]byte, (0x10-len(moduled)%0x10)%0x10)...)
+	moduled = append(moduled, second...)
+
+	tests := []struct {
+		name  string
+		input []byte
+		want  []byte
+	}{
+		{
+			name:  "Test with single module",
+			input: []byte{0x00, 0x06, 0x59, 0x00, 0x41, 0xFF, 0x00, 0xF0, 0x00},
+			want:  []byte("AAAAAA"),
+		},
+		{
+			name:  "Test with padded modules",
+			input: moduled,
+			want:  data,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rom := generic.ROM{Data: tt.input, Size: len(tt.input)}
+			got := types.NewMDCompressor("KOSINSKIM", rom).Unmarshal()
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("Unmarshal() = %X, want %X", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMDCompressor_KOSINSKIM_Marshal(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	noise := make([]byte, 0x2345)
+	random.Read(noise)
+
+	tests := []struct {
+		name  string
+		input []byte
+	}{
+		{name: "Test with single module", input: bytes.Repeat([]byte("KNUCKLES"), 0x100)},
+		{name: "Test with exact modules", input: make([]byte, 0x3000)},
+		{name: "Test with partial module", input: noise},
+		{name: "Test with largest size", input: make([]byte, 0xFFFF)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rom := generic.ROM{Data: tt.input, Size: len(tt.input)}
+			compressed := types.NewMDCompressor("KOSINSKIM", rom).Marshal()
+			rom = generic.ROM{Data: compressed, Size: len(compressed)}
+			got := types.NewMDCompressor("KOSINSKIM", rom).Unmarshal()
+			if !bytes.Equal(got, tt.input) {
+				t.Errorf("Unmarshal(Marshal()) = %X, want %X", got, tt.input)
+			}
+		})
+	}
+}
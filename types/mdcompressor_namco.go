@@ -0,0 +1,22 @@
+package types
+
+import "github.com/hansbonini/go-segamd/types/generic"
+
+// MDCompressor_NAMCO is reserved for the LZ variant shared by Namco titles such as Phelios. No
+// block from those games has been decoded yet, so it does nothing and is not listed in
+// MDCompressorAlgorithms.
+type MDCompressor_NAMCO struct {
+	ROM generic.ROM
+}
+
+func (namco *MDCompressor_NAMCO) Marshal() []byte {
+	return []byte{}
+}
+
+func (namco *MDCompressor_NAMCO) Unmarshal() []byte {
+	return []byte{}
+}
+
+func (namco *MDCompressor_NAMCO) UnmarshalAt(offset int) ([]byte, int) {
+	return []byte{}, 0
+}
@@ -0,0 +1,329 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"slices"
+	"sort"
+
+	"github.com/hansbonini/go-segamd/types/generic"
+)
+
+type MDCompressor_NEMESIS struct {
+	ROM generic.ROM
+}
+
+type nemesisCode struct {
+	Nybble uint8
+	Count  int
+	Length int
+	Code   uint32
+}
+
+// Unmarshal decodes the NEMESIS compression format from the ROM and returns the decompressed data.
+//
+// The stream starts with a 16-bit header whose highest bit enables the XOR mode and whose
+// remaining 15 bits hold the number of 8x8 tiles to decode. The header is followed by the
+// code table: a byte with the highest bit set selects the nybble for the next entries, and each
+// entry is a pair of bytes holding the run length (bits 4-6) and code length (bits 0-3), followed
+// by the code itself. The table ends with a 0xFF byte.
+// The rest of the stream is read MSB first. Each code expands to a run of 1 to 8 equal nybbles.
+// A code starting with 6 set bits is an inline run, where the next 3 bits hold the run length and
+// the following 4 bits hold the nybble value. Every 8 nybbles make a row of 4 bytes, and in XOR
+// mode each row is XORed with the previously decoded row.
+//
+// Returns:
+// - []byte: the decompressed data.
+func (nemesis *MDCompressor_NEMESIS) Unmarshal() []byte {
+	var header uint16
+	var err error
+	if header, err = nemesis.ROM.Read16(); err != nil {
+		return []byte{}
+	}
+	table, ok := nemesis.readCodeTable()
+	if !ok {
+		return []byte{}
+	}
+	xor := header&0x8000 != 0
+	rows := int(header&0x7FFF) * 8
+	buffer := new(bytes.Buffer)
+	reader := newMDBitReader(&nemesis.ROM)
+	var row, previous uint32
+	nybbles := 0
+	for rows > 0 && !reader.Exhausted() {
+		var value uint8
+		var count int
+		if prefix := reader.Peek(8); prefix >= 0xFC {
+			reader.Skip(6)
+			inline := reader.Read(7)
+			count = int(inline>>4) + 1
+			value = uint8(inline & 0x0F)
+		} else if code := table[prefix]; code.Length > 0 {
+			reader.Skip(code.Length)
+			count = code.Count
+			value = code.Nybble
+		} else {
+			break
+		}
+		for ; count > 0 && rows > 0; count-- {
+			row = row<<4 | uint32(value)
+			nybbles++
+			if nybbles == 8 {
+				if xor {
+					row ^= previous
+					previous = row
+				}
+				binary.Write(buffer, binary.BigEndian, row)
+				row = 0
+				nybbles = 0
+				rows--
+			}
+		}
+	}
+	return buffer.Bytes()
+}
+
+// UnmarshalAt decodes the NEMESIS compression format starting at the given offset of the ROM.
+//
+// Parameters:
+// - offset: the offset of the ROM where the compressed data starts.
+//
+// Returns:
+// - []byte: the decompressed data.
+// - int: the number of bytes read from the offset, that is, the compressed size.
+func (nemesis *MDCompressor_NEMESIS) UnmarshalAt(offset int) ([]byte, int) {
+	return mdUnmarshalAt(&nemesis.ROM, offset, nemesis.Unmarshal)
+}
+
+// readCodeTable reads the NEMESIS code table from the ROM into a lookup table indexed by the
+// next 8 bits of the stream.
+//
+// Returns:
+// - [256]nemesisCode: the lookup table, where entries with zero length are unused.
+// - bool: false if the table is malformed or truncated.
+func (nemesis *MDCompressor_NEMESIS) readCodeTable() (table [256]nemesisCode, ok bool) {
+	var value, entry, code uint8
+	var err error
+	if value, err = nemesis.ROM.Read8(); err != nil {
+		return table, false
+	}
+	for value != 0xFF {
+		if value&0x80 == 0 {
+			return table, false
+		}
+		nybble := value & 0x0F
+		for {
+			if entry, err = nemesis.ROM.Read8(); err != nil {
+				return table, false
+			}
+			if entry&0x80 != 0 {
+				value = entry
+				break
+			}
+			if code, err = nemesis.ROM.Read8(); err != nil {
+				return table, false
+			}
+			length := int(entry & 0x0F)
+			if length == 0 || length > 8 {
+				return table, false
+			}
+			shift := 8 - length
+			first := int(code) << shift & 0xFF
+			for i := 0; i < 1<<shift; i++ {
+				table[first+i] = nemesisCode{
+					Nybble: nybble,
+					Count:  int(entry>>4&0x07) + 1,
+					Length: length,
+					Code:   uint32(code),
+				}
+			}
+		}
+	}
+	return table, true
+}
+
+// Marshal compresses the ROM data using the NEMESIS compression algorithm and returns the compressed data as a byte slice.
+//
+// The data is padded to a multiple of 0x20 bytes (one 8x8 tile) and encoded twice, once as is and
+// once in XOR mode, keeping the smallest result. For each mode the nybbles are split into runs of up
+// to 8 equal values, and the code table is built by assigning to each run the code length that
+// minimizes the size of the whole stream, leaving rare runs to be written inline.
+//
+// The header holds at most 0x7FFF tiles, so larger data is not compressed at all.
+//
+// Returns:
+// - []byte: the compressed data as a byte slice, or an empty slice if the data holds more than 0x7FFF tiles.
+func (nemesis *MDCompressor_NEMESIS) Marshal() []byte {
+	data := nemesis.ROM.Data
+	if len(data)%0x20 != 0 {
+		data = append(slices.Clone(data), make([]byte, 0x20-len(data)%0x20)...)
+	}
+	if len(data) > 0x7FFF*0x20 {
+		return []byte{}
+	}
+	rows := make([]uint32, len(data)/4)
+	for i := range rows {
+		rows[i] = binary.BigEndian.Uint32(data[i*4:])
+	}
+	out := nemesis.encode(rows, false)
+	if xored := nemesis.encode(rows, true); len(xored) < len(out) {
+		out = xored
+	}
+	return out
+}
+
+// encode writes the header, code table and bitstream for the given rows.
+//
+// Parameters:
+// - rows: the data to compress, as 32-bit rows of 8 nybbles.
+// - xor: whether each row must be XORed with the previous one before encoding.
+//
+// Returns:
+// - []byte: the compressed data as a byte slice.
+func (nemesis *MDCompressor_NEMESIS) encode(rows []uint32, xor bool) []byte {
+	runs := make([]nemesisCode, 0)
+	var previous uint32
+	for _, row := range rows {
+		value := row
+		if xor {
+			value ^= previous
+			previous = row
+		}
+		for i := 7; i >= 0; i-- {
+			nybble := uint8(value >> (i * 4) & 0x0F)
+			if last := len(runs) - 1; last >= 0 && runs[last].Nybble == nybble && runs[last].Count < 8 {
+				runs[last].Count++
+			} else {
+				runs = append(runs, nemesisCode{Nybble: nybble, Count: 1})
+			}
+		}
+	}
+	codes := nemesis.buildCodes(runs)
+
+	out := new(bytes.Buffer)
+	header := uint16(len(rows) / 8)
+	if xor {
+		header |= 0x8000
+	}
+	binary.Write(out, binary.BigEndian, header)
+	lastNybble := -1
+	for _, code := range codes {
+		if int(code.Nybble) != lastNybble {
+			out.WriteByte(0x80 | code.Nybble)
+			lastNybble = int(code.Nybble)
+		}
+		out.WriteByte(byte(code.Count-1)<<4 | byte(code.Length))
+		out.WriteByte(byte(code.Code))
+	}
+	out.WriteByte(0xFF)
+
+	lookup := make(map[[2]int]nemesisCode)
+	for _, code := range codes {
+		lookup[[2]int{int(code.Nybble), code.Count}] = code
+	}
+	writer := newMDBitWriter(out)
+	for _, run := range runs {
+		if code, ok := lookup[[2]int{int(run.Nybble), run.Count}]; ok {
+			writer.Write(code.Code, code.Length)
+		} else {
+			writer.Write(0x3F, 6)
+			writer.Write(uint32(run.Count-1)<<4|uint32(run.Nybble), 7)
+		}
+	}
+	writer.Flush()
+	return out.Bytes()
+}
+
+// buildCodes chooses which runs get an entry in the code table and assigns them prefix codes.
+//
+// The runs are sorted by frequency and a dynamic programming pass picks, for each of them, either a
+// code length from 1 to 8 bits or the 13-bit inline form, minimizing the stream size plus the 2 bytes
+// spent by each table entry. The Kraft sum of the chosen lengths is kept below 63/64, so canonical
+// codes never start with the 6 set bits reserved for inline runs.
+//
+// Parameters:
+// - runs: the sequence of runs to be encoded.
+//
+// Returns:
+// - []nemesisCode: the code table entries sorted by nybble, run length and code.
+func (nemesis *MDCompressor_NEMESIS) buildCodes(runs []nemesisCode) []nemesisCode {
+	const budget = 252
+	const escape = 9
+	frequencies := make(map[[2]int]int)
+	for _, run := range runs {
+		frequencies[[2]int{int(run.Nybble), run.Count}]++
+	}
+	symbols := make([]nemesisCode, 0, len(frequencies))
+	for key := range frequencies {
+		symbols = append(symbols, nemesisCode{Nybble: uint8(key[0]), Count: key[1]})
+	}
+	sort.Slice(symbols, func(i, j int) bool {
+		fi := frequencies[[2]int{int(symbols[i].Nybble), symbols[i].Count}]
+		fj := frequencies[[2]int{int(symbols[j].Nybble), symbols[j].Count}]
+		if fi != fj {
+			return fi > fj
+		}
+		if symbols[i].Nybble != symbols[j].Nybble {
+			return symbols[i].Nybble < symbols[j].Nybble
+		}
+		return symbols[i].Count < symbols[j].Count
+	})
+
+	// cost[i][b][l] is the cheapest encoding of symbols[i:] when b/256 of the
+	// code space is used and no code shorter than l bits may follow.
+	n := len(symbols)
+	index := func(i, b, l int) int {
+		return (i*(budget+1)+b)*(escape+1) + l
+	}
+	cost := make([]int, (n+1)*(budget+1)*(escape+1))
+	choice := make([]int8, len(cost))
+	for i := n - 1; i >= 0; i-- {
+		frequency := frequencies[[2]int{int(symbols[i].Nybble), symbols[i].Count}]
+		for b := 0; b <= budget; b++ {
+			for l := 1; l <= escape; l++ {
+				best := frequency*13 + cost[index(i+1, b, escape)]
+				selected := escape
+				for length := l; length <= 8; length++ {
+					width := 1 << (8 - length)
+					if b+width > budget {
+						continue
+					}
+					if c := frequency*length + 16 + cost[index(i+1, b+width, length)]; c < best {
+						best = c
+						selected = length
+					}
+				}
+				cost[index(i, b, l)] = best
+				choice[index(i, b, l)] = int8(selected)
+			}
+		}
+	}
+
+	codes := make([]nemesisCode, 0)
+	b, l := 0, 1
+	for i := 0; i < n; i++ {
+		length := int(choice[index(i, b, l)])
+		if length == escape {
+			break
+		}
+		symbols[i].Length = length
+		codes = append(codes, symbols[i])
+		b += 1 << (8 - length)
+		l = length
+	}
+
+	code, length := uint32(0), 0
+	for i := range codes {
+		code <<= codes[i].Length - length
+		length = codes[i].Length
+		codes[i].Code = code
+		code++
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		if codes[i].Nybble != codes[j].Nybble {
+			return codes[i].Nybble < codes[j].Nybble
+		}
+		return codes[i].Count < codes[j].Count
+	})
+	return codes
+}
@@ -0,0 +1,95 @@
+package types_test
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/hansbonini/go-segamd/types"
+	"github.com/hansbonini/go-segamd/types/generic"
+)
+
+func TestMDCompressor_NEMESIS_Unmarshal(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+		want  []byte
+	}{
+		{
+			name:  "Test with single code",
+			input: []byte{0x00, 0x01, 0x80, 0x71, 0x00, 0xFF, 0x00},
+			want:  bytes.Repeat([]byte{0x00}, 0x20),
+		},
+		{
+			name: "Test with inline runs",
+			input: []byte{
+				0x00, 0x01, 0xFF,
+				0xFF, 0xAF, 0xFD, 0x7F, 0xEB, 0xFF, 0x5F, 0xFA, 0xFF, 0xD7, 0xFE, 0xBF, 0xF5,
+			},
+			want: bytes.Repeat([]byte{0x55}, 0x20),
+		},
+		{
+			name:  "Test with XOR mode",
+			input: []byte{0x80, 0x01, 0x80, 0x71, 0x00, 0x81, 0x72, 0x02, 0xFF, 0x80, 0x00},
+			want:  bytes.Repeat([]byte{0x11}, 0x20),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rom := generic.ROM{Data: tt.input, Size: len(tt.input)}
+			got := types.NewMDCompressor("NEMESIS", rom).Unmarshal()
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("Unmarshal() = %X, want %X", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMDCompressor_NEMESIS_Marshal(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	gradient := make([]byte, 0x400)
+	for i := range gradient {
+		gradient[i] = byte(i/0x20) & 0x0F * 0x11
+	}
+	sparse := make([]byte, 0x400)
+	for i := range sparse {
+		if random.Intn(8) == 0 {
+			sparse[i] = byte(random.Intn(16))
+		}
+	}
+
+	tests := []struct {
+		name  string
+		input []byte
+	}{
+		{name: "Test with gradient", input: gradient},
+		{name: "Test with sparse data", input: sparse},
+		{name: "Test with partial tile", input: []byte{0x12, 0x34, 0x56}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rom := generic.ROM{Data: tt.input, Size: len(tt.input)}
+			compressed := types.NewMDCompressor("NEMESIS", rom).Marshal()
+			rom = generic.ROM{Data: compressed, Size: len(compressed)}
+			got := types.NewMDCompressor("NEMESIS", rom).Unmarshal()
+			want := append(bytes.Clone(tt.input), make([]byte, (0x20-len(tt.input)%0x20)%0x20)...)
+			if !bytes.Equal(got, want) {
+				t.Errorf("Unmarshal(Marshal()) = %X, want %X", got, want)
+			}
+		})
+	}
+}
+
+func TestMDCompressor_NEMESIS_Marshal_TileLimit(t *testing.T) {
+	input := make([]byte, 0x7FFF*0x20)
+	rom := generic.ROM{Data: input, Size: len(input)}
+	compressed := types.NewMDCompressor("NEMESIS", rom).Marshal()
+	if len(compressed) < 2 {
+		t.Fatalf("Marshal() of 0x7FFF tiles = %X", compressed)
+	}
+	if header := int(compressed[0]&0x7F)<<8 | int(compressed[1]); header != 0x7FFF {
+		t.Errorf("Marshal() of 0x7FFF tiles wrote a header of 0x%X tiles", header)
+	}
+}
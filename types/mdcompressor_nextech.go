@@ -0,0 +1,22 @@
+package types
+
+import "github.com/hansbonini/go-segamd/types/generic"
+
+// MDCompressor_NEXTECH is reserved for the compression of Crusader of Centy. The format has not been
+// reversed from the game decompressor, so it decodes and encodes nothing and is not listed in
+// MDCompressorAlgorithms.
+type MDCompressor_NEXTECH struct {
+	ROM generic.ROM
+}
+
+func (nextech *MDCompressor_NEXTECH) Marshal() []byte {
+	return []byte{}
+}
+
+func (nextech *MDCompressor_NEXTECH) Unmarshal() []byte {
+	return []byte{}
+}
+
+func (nextech *MDCompressor_NEXTECH) UnmarshalAt(offset int) ([]byte, int) {
+	return []byte{}, 0
+}
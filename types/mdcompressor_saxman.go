@@ -0,0 +1,149 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/hansbonini/go-segamd/types/generic"
+)
+
+type MDCompressor_SAXMAN struct {
+	ROM        generic.ROM
+	Headerless bool
+	// Size is the compressed size of a headerless stream, as stored elsewhere by the game. If zero,
+	// the stream runs to the end of the ROM.
+	Size int
+}
+
+const (
+	saxmanLiteral = iota
+	saxmanCopy
+)
+
+var saxmanFlags = mdFlagFormat{Width: 8, LSBFirst: true}
+
+// Unmarshal decodes the SAXMAN compression format from the ROM and returns the decompressed data.
+//
+// Unless Headerless is set, the stream starts with a 16-bit little endian size of the compressed
+// data that follows; otherwise Size bytes are decoded or, if it is zero, the rest of the ROM, as done
+// for the Sonic 2 sound driver. The data is driven by 8-bit descriptor fields read LSB first, where 1 is a literal byte and
+// 0 is a copy packed in two bytes as LLLLLLLL HHHHCCCC. The copy is CCCC+3 bytes long and its source
+// is the 12-bit position HHHHLLLLLLLL of a 0x1000-byte window starting at 0xFEE, with positions before
+// the start of the output reading as zero.
+//
+// Returns:
+// - []byte: the decompressed data.
+func (saxman *MDCompressor_SAXMAN) Unmarshal() []byte {
+	var bit, low, high, value uint8
+	var err error
+	size := saxman.Size
+	if !saxman.Headerless {
+		if low, err = saxman.ROM.Read8(); err != nil {
+			return []byte{}
+		}
+		if high, err = saxman.ROM.Read8(); err != nil {
+			return []byte{}
+		}
+		size = int(high)<<8 | int(low)
+	}
+	bounded := !saxman.Headerless || saxman.Size > 0
+	start := saxman.ROM.Offset
+	out := make([]byte, 0)
+	reader := newMDFlagReader(&saxman.ROM, saxmanFlags)
+	for !bounded || saxman.ROM.Offset-start < size {
+		if bit, err = reader.ReadBit(); err != nil {
+			break
+		}
+		if bit == 1 {
+			if value, err = saxman.ROM.Read8(); err != nil {
+				break
+			}
+			out = append(out, value)
+			continue
+		}
+		if low, err = saxman.ROM.Read8(); err != nil {
+			break
+		}
+		if high, err = saxman.ROM.Read8(); err != nil {
+			break
+		}
+		count := int(high&0x0F) + 3
+		position := int(high&0xF0)<<4 | int(low)
+		distance := (len(out) - position - 0x12) & 0xFFF
+		if distance == 0 {
+			distance = 0x1000
+		}
+		if distance > len(out) {
+			out = append(out, make([]byte, count)...)
+			continue
+		}
+		out, _ = lzssCopy(out, distance, count)
+	}
+	return out
+}
+
+// UnmarshalAt decodes the SAXMAN compression format starting at the given offset of the ROM.
+//
+// Parameters:
+// - offset: the offset of the ROM where the compressed data starts.
+//
+// Returns:
+// - []byte: the decompressed data.
+// - int: the number of bytes read from the offset, that is, the compressed size.
+func (saxman *MDCompressor_SAXMAN) UnmarshalAt(offset int) ([]byte, int) {
+	return mdUnmarshalAt(&saxman.ROM, offset, saxman.Unmarshal)
+}
+
+// Marshal compresses the ROM data using the SAXMAN compression algorithm and returns the compressed data as a byte slice.
+//
+// The commands are chosen by an optimal parse of the data, which also takes advantage of the zeros
+// read before the start of the output. The size header is omitted if Headerless is set, otherwise
+// a compressed stream larger than the 0xFFFF bytes it can describe is not written at all.
+//
+// Returns:
+// - []byte: the compressed data as a byte slice, or an empty slice if the size header overflows.
+func (saxman *MDCompressor_SAXMAN) Marshal() []byte {
+	const window = 0x1000
+	data := append(make([]byte, window), saxman.ROM.Data...)
+	steps := lzssParse(data, window, window, 2, 0x12, func(pos int, matches []lzssMatch, add func(lzssStep, int)) {
+		add(lzssStep{Length: 1, Kind: saxmanLiteral}, 9)
+		length := 2
+		for _, match := range matches {
+			for l := length + 1; l <= match.Length; l++ {
+				if source := pos - match.Distance; source >= window || source+l <= window {
+					add(lzssStep{Length: l, Distance: match.Distance, Kind: saxmanCopy}, 17)
+				}
+			}
+			length = max(length, match.Length)
+		}
+	})
+
+	stream := new(bytes.Buffer)
+	writer := newMDFlagWriter(stream, saxmanFlags)
+	pos := window
+	for _, step := range steps {
+		switch step.Kind {
+		case saxmanLiteral:
+			writer.WriteBit(1)
+			writer.WriteByte(data[pos])
+		case saxmanCopy:
+			position := (pos - step.Distance - window - 0x12) & 0xFFF
+			writer.WriteBit(0)
+			writer.WriteByte(byte(position))
+			writer.WriteByte(byte(position>>4)&0xF0 | byte(step.Length-3))
+		}
+		pos += step.Length
+	}
+	writer.Close()
+
+	if saxman.Headerless {
+		return stream.Bytes()
+	}
+	if stream.Len() > 0xFFFF {
+		return []byte{}
+	}
+	out := new(bytes.Buffer)
+	binary.Write(out, binary.LittleEndian, uint16(stream.Len()))
+	out.Write(stream.Bytes())
+	return out.Bytes()
+}
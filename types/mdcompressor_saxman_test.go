@@ -0,0 +1,130 @@
+package types_test
+
+import (
+	"bytes"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/hansbonini/go-segamd/types"
+	"github.com/hansbonini/go-segamd/types/generic"
+)
+
+func TestMDCompressor_SAXMAN_Unmarshal(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      []byte
+		headerless bool
+		want       []byte
+	}{
+		{
+			name:  "Test with size header",
+			input: []byte{0x04, 0x00, 0x01, 0x41, 0xEE, 0xF2, 0xFF, 0xFF},
+			want:  []byte("AAAAAA"),
+		},
+		{
+			name:       "Test without size header",
+			input:      []byte{0x02, 0x00, 0x00, 0x42},
+			headerless: true,
+			want:       []byte("\x00\x00\x00B"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saxman := &types.MDCompressor_SAXMAN{
+				ROM:        generic.ROM{Data: tt.input, Size: len(tt.input)},
+				Headerless: tt.headerless,
+			}
+			if got := saxman.Unmarshal(); !bytes.Equal(got, tt.want) {
+				t.Errorf("Unmarshal() = %X, want %X", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMDCompressor_SAXMAN_Marshal(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	noise := make([]byte, 0x800)
+	random.Read(noise)
+	driver := make([]byte, 0x2000)
+	for i := range driver {
+		if i%0x100 > 0x20 {
+			driver[i] = byte(random.Intn(8))
+		}
+	}
+
+	tests := []struct {
+		name  string
+		input []byte
+	}{
+		{name: "Test with empty data", input: []byte{}},
+		{name: "Test with zero data", input: make([]byte, 0x1000)},
+		{name: "Test with noise", input: noise},
+		{name: "Test with driver", input: driver},
+	}
+
+	for _, tt := range tests {
+		for _, headerless := range []bool{false, true} {
+			t.Run(tt.name, func(t *testing.T) {
+				saxman := &types.MDCompressor_SAXMAN{
+					ROM:        generic.ROM{Data: tt.input, Size: len(tt.input)},
+					Headerless: headerless,
+				}
+				compressed := saxman.Marshal()
+				saxman.ROM = generic.ROM{Data: compressed, Size: len(compressed)}
+				if got := saxman.Unmarshal(); !bytes.Equal(got, tt.input) {
+					t.Errorf("Unmarshal(Marshal()) = %X, want %X", got, tt.input)
+				}
+			})
+		}
+	}
+}
+
+func TestMDCompressor_SAXMAN_Marshal_Headerless(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	noise := make([]byte, 0x10000)
+	random.Read(noise)
+
+	saxman := &types.MDCompressor_SAXMAN{ROM: generic.ROM{Data: noise, Size: len(noise)}, Headerless: true}
+	if got := saxman.Marshal(); len(got) <= 0xFFFF {
+		t.Errorf("Marshal() without header = %d bytes, want the whole stream", len(got))
+	}
+}
+
+func TestMDCompressor_SAXMAN_UnmarshalAt(t *testing.T) {
+	input := bytes.Repeat([]byte("SONIC 2 SOUND DRIVER "), 0x20)
+	padding := []byte{0xDE, 0xAD, 0xBE, 0xEF, 0x01}
+	saxman := &types.MDCompressor_SAXMAN{ROM: generic.ROM{Data: input, Size: len(input)}, Headerless: true}
+	compressed := saxman.Marshal()
+	data := append(append(slices.Clone(padding), compressed...), padding...)
+
+	tests := []struct {
+		name string
+		size int
+		read int
+	}{
+		{name: "Test with compressed size", size: len(compressed), read: len(compressed)},
+		{name: "Test without compressed size", read: len(compressed) + len(padding)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saxman := &types.MDCompressor_SAXMAN{
+				ROM:        generic.ROM{Data: data, Size: len(data)},
+				Headerless: true,
+				Size:       tt.size,
+			}
+			got, read := saxman.UnmarshalAt(len(padding))
+			if read != tt.read {
+				t.Errorf("UnmarshalAt() read 0x%X bytes, want 0x%X", read, tt.read)
+			}
+			if !bytes.HasPrefix(got, input) {
+				t.Errorf("UnmarshalAt() = %X, want it to start with %X", got, input)
+			}
+			if tt.size != 0 && len(got) != len(input) {
+				t.Errorf("UnmarshalAt() = 0x%X bytes, want 0x%X", len(got), len(input))
+			}
+		})
+	}
+}
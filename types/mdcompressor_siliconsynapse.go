@@ -0,0 +1,22 @@
+package types
+
+import "github.com/hansbonini/go-segamd/types/generic"
+
+// MDCompressor_SILICONSYNAPSE is reserved for the compression of The Lost Vikings and Rock n' Roll
+// Racing. It has not been checked against a block from either game, so it decodes and encodes
+// nothing and is not listed in MDCompressorAlgorithms.
+type MDCompressor_SILICONSYNAPSE struct {
+	ROM generic.ROM
+}
+
+func (siliconsynapse *MDCompressor_SILICONSYNAPSE) Marshal() []byte {
+	return []byte{}
+}
+
+func (siliconsynapse *MDCompressor_SILICONSYNAPSE) Unmarshal() []byte {
+	return []byte{}
+}
+
+func (siliconsynapse *MDCompressor_SILICONSYNAPSE) UnmarshalAt(offset int) ([]byte, int) {
+	return []byte{}, 0
+}
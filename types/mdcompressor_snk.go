@@ -0,0 +1,22 @@
+package types
+
+import "github.com/hansbonini/go-segamd/types/generic"
+
+// MDCompressor_SNK is reserved for the compression of the SNK ports such as Fatal Fury. No block
+// from a ROM dump has confirmed a layout, so it decodes and encodes nothing and is not listed in
+// MDCompressorAlgorithms.
+type MDCompressor_SNK struct {
+	ROM generic.ROM
+}
+
+func (snk *MDCompressor_SNK) Marshal() []byte {
+	return []byte{}
+}
+
+func (snk *MDCompressor_SNK) Unmarshal() []byte {
+	return []byte{}
+}
+
+func (snk *MDCompressor_SNK) UnmarshalAt(offset int) ([]byte, int) {
+	return []byte{}, 0
+}
@@ -0,0 +1,22 @@
+package types
+
+import "github.com/hansbonini/go-segamd/types/generic"
+
+// MDCompressor_SOFTWARECREATIONS is reserved for the LZ/RLE hybrid of Maximum Carnage. No
+// documentation or reference decoder backs a layout yet, so it decodes and encodes nothing and is
+// not listed in MDCompressorAlgorithms.
+type MDCompressor_SOFTWARECREATIONS struct {
+	ROM generic.ROM
+}
+
+func (softwarecreations *MDCompressor_SOFTWARECREATIONS) Marshal() []byte {
+	return []byte{}
+}
+
+func (softwarecreations *MDCompressor_SOFTWARECREATIONS) Unmarshal() []byte {
+	return []byte{}
+}
+
+func (softwarecreations *MDCompressor_SOFTWARECREATIONS) UnmarshalAt(offset int) ([]byte, int) {
+	return []byte{}, 0
+}
@@ -0,0 +1,40 @@
+package types
+
+import "github.com/hansbonini/go-segamd/types/generic"
+
+// MDCompressor_STI is kept for the STI format of Comix Zone and its siblings, but without a block
+// dumped from one of those games to check a layout against, it decodes and encodes nothing and
+// is not listed in MDCompressorAlgorithms.
+type MDCompressor_STI struct {
+	ROM generic.ROM
+}
+
+func (sti *MDCompressor_STI) Marshal() []byte {
+	return []byte{}
+}
+
+func (sti *MDCompressor_STI) Unmarshal() []byte {
+	return []byte{}
+}
+
+func (sti *MDCompressor_STI) UnmarshalAt(offset int) ([]byte, int) {
+	return []byte{}, 0
+}
+
+// MDCompressor_STI2 is the second STI revision, left unimplemented and unregistered for the same
+// reason as MDCompressor_STI.
+type MDCompressor_STI2 struct {
+	ROM generic.ROM
+}
+
+func (sti2 *MDCompressor_STI2) Marshal() []byte {
+	return []byte{}
+}
+
+func (sti2 *MDCompressor_STI2) Unmarshal() []byte {
+	return []byte{}
+}
+
+func (sti2 *MDCompressor_STI2) UnmarshalAt(offset int) ([]byte, int) {
+	return []byte{}, 0
+}
@@ -0,0 +1,22 @@
+package types
+
+import "github.com/hansbonini/go-segamd/types/generic"
+
+// MDCompressor_TECHNOSOFT is meant for the Thunder Force and Herzog Zwei graphics. Its ring window
+// layout has not been checked against data from those games, so it decodes and encodes nothing
+// and is not listed in MDCompressorAlgorithms.
+type MDCompressor_TECHNOSOFT struct {
+	ROM generic.ROM
+}
+
+func (technosoft *MDCompressor_TECHNOSOFT) Marshal() []byte {
+	return []byte{}
+}
+
+func (technosoft *MDCompressor_TECHNOSOFT) Unmarshal() []byte {
+	return []byte{}
+}
+
+func (technosoft *MDCompressor_TECHNOSOFT) UnmarshalAt(offset int) ([]byte, int) {
+	return []byte{}, 0
+}
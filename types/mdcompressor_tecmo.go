@@ -0,0 +1,22 @@
+package types
+
+import "github.com/hansbonini/go-segamd/types/generic"
+
+// MDCompressor_TECMO is reserved for the roster and graphics compression of Tecmo sports games.
+// Its commands have not been taken from a disassembly, so it decodes and encodes nothing and is
+// not listed in MDCompressorAlgorithms.
+type MDCompressor_TECMO struct {
+	ROM generic.ROM
+}
+
+func (tecmo *MDCompressor_TECMO) Marshal() []byte {
+	return []byte{}
+}
+
+func (tecmo *MDCompressor_TECMO) Unmarshal() []byte {
+	return []byte{}
+}
+
+func (tecmo *MDCompressor_TECMO) UnmarshalAt(offset int) ([]byte, int) {
+	return []byte{}, 0
+}
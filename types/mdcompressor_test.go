@@ -0,0 +1,189 @@
+package types_test
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/hansbonini/go-segamd/types"
+	"github.com/hansbonini/go-segamd/types/generic"
+)
+
+func TestNewMDCompressor(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	noise := make([]byte, 0x800)
+	random.Read(noise)
+	script := bytes.Repeat([]byte("SEGA GENESIS / MEGA DRIVE "), 0x50)[:0x800]
+	for i := 0; i < len(script); i += 0x37 {
+		script[i] = byte(random.Intn(0x100))
+	}
+	tiles := make([]byte, 0x800)
+	for i := range tiles {
+		tiles[i] = byte(i/0x40%4*0x11) ^ byte(i%4/3*0x12)
+	}
+
+	inputs := []struct {
+		name  string
+		input []byte
+	}{
+		{name: "empty", input: []byte{}},
+		{name: "zero", input: make([]byte, 0x1000)},
+		{name: "noise", input: noise},
+		{name: "script", input: script},
+		{name: "tiles", input: tiles},
+	}
+
+	for _, algorithm := range types.MDCompressorAlgorithms {
+		for _, name := range append([]string{algorithm.Name}, algorithm.Aliases...) {
+			if compressor := types.NewMDCompressor(name, generic.ROM{}); compressor == nil {
+				t.Errorf("NewMDCompressor(%q) = nil", name)
+			}
+		}
+		for _, tt := range inputs {
+			t.Run(algorithm.Name+"/"+tt.name, func(t *testing.T) {
+				rom := generic.ROM{Data: tt.input, Size: len(tt.input)}
+				compressed := types.NewMDCompressor(algorithm.Name, rom).Marshal()
+				if len(tt.input) > 0 && len(compressed) == 0 {
+					t.Fatalf("Marshal() returned an empty slice")
+				}
+				rom = generic.ROM{Data: compressed, Size: len(compressed)}
+				if got := types.NewMDCompressor(algorithm.Name, rom).Unmarshal(); !bytes.Equal(got, tt.input) {
+					t.Errorf("Unmarshal(Marshal()) = %X, want %X", got, tt.input)
+				}
+			})
+		}
+	}
+}
+
+func TestNewMDCompressorWithParameters(t *testing.T) {
+	rom := generic.ROM{Data: []byte{}, Size: 0}
+	tests := []struct {
+		algorithm  string
+		parameters map[string]int
+		want       types.MDCompressor
+	}{
+		{"SAXMAN", map[string]int{"headerless": 1}, &types.MDCompressor_SAXMAN{ROM: rom, Headerless: true}},
+		{"SAXMAN", map[string]int{"headerless": 1, "size": 0x100}, &types.MDCompressor_SAXMAN{ROM: rom, Headerless: true, Size: 0x100}},
+		{"ENIGMA", map[string]int{"start-tile": 0x2000}, &types.MDCompressor_ENIGMA{ROM: rom, StartTile: 0x2000}},
+		{"EASTRIKE", map[string]int{"header": types.EAStrikeHeaderLongSizes}, &types.MDCompressor_EASTRIKE{ROM: rom, Header: types.EAStrikeHeaderLongSizes}},
+		{"KOZINSKI", nil, &types.MDCompressor_KOZINSKI{ROM: rom}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			if got := types.NewMDCompressorWithParameters(tt.algorithm, rom, tt.parameters); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewMDCompressorWithParameters() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+
+	for _, algorithm := range types.MDCompressorAlgorithms {
+		for _, parameter := range algorithm.Parameters {
+			if parameter.Name == "" || parameter.Usage == "" {
+				t.Errorf("%s has a parameter without a name or usage: %+v", algorithm.Name, parameter)
+			}
+		}
+	}
+	if got := types.NewMDCompressorWithParameters("UNKNOWN", rom, nil); got != nil {
+		t.Errorf("NewMDCompressorWithParameters(\"UNKNOWN\") = %#v, want nil", got)
+	}
+}
+
+func TestMDCompressorUnmarshalAt(t *testing.T) {
+	input := make([]byte, 0x200)
+	for i := range input {
+		input[i] = byte(i/0x10*0x11) ^ byte(i%3)
+	}
+	padding := []byte{0xDE, 0xAD, 0xBE, 0xEF, 0x01}
+
+	for _, algorithm := range types.MDCompressorAlgorithms {
+		t.Run(algorithm.Name, func(t *testing.T) {
+			rom := generic.ROM{Data: input, Size: len(input)}
+			compressed := types.NewMDCompressor(algorithm.Name, rom).Marshal()
+			data := append(append(slices.Clone(padding), compressed...), padding...)
+			rom = generic.ROM{Data: data, Size: len(data)}
+			got, size := types.NewMDCompressor(algorithm.Name, rom).UnmarshalAt(len(padding))
+			if !bytes.Equal(got, input) {
+				t.Errorf("UnmarshalAt() = %X, want %X", got, input)
+			}
+			if size != len(compressed) {
+				t.Errorf("UnmarshalAt() read 0x%X bytes, want 0x%X", size, len(compressed))
+			}
+
+			rom = generic.ROM{Data: padding, Size: len(padding)}
+			for _, offset := range []int{-1, len(padding)} {
+				if got, size := types.NewMDCompressor(algorithm.Name, rom).UnmarshalAt(offset); len(got) != 0 || size != 0 {
+					t.Errorf("UnmarshalAt(%d) = %X, 0x%X, want an empty slice and 0", offset, got, size)
+				}
+			}
+		})
+	}
+}
+
+func TestMDCompressorSizeLimit(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	noise := make([]byte, 0x10000)
+	random.Read(noise)
+
+	tests := []struct {
+		algorithm string
+		input     []byte
+	}{
+		{algorithm: "NEMESIS", input: make([]byte, 0x7FFF*0x20+1)},
+		{algorithm: "KOSINSKIM", input: make([]byte, 0x10000)},
+		{algorithm: "SAXMAN", input: noise},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			rom := generic.ROM{Data: tt.input, Size: len(tt.input)}
+			if got := types.NewMDCompressor(tt.algorithm, rom).Marshal(); len(got) != 0 {
+				t.Errorf("Marshal() of 0x%X bytes = 0x%X bytes, want an empty slice", len(tt.input), len(got))
+			}
+		})
+	}
+}
+
+func TestMDCompressorTruncated(t *testing.T) {
+	input := bytes.Repeat([]byte("SEGA GENESIS / MEGA DRIVE "), 0x40)
+
+	for _, algorithm := range types.MDCompressorAlgorithms {
+		t.Run(algorithm.Name, func(t *testing.T) {
+			rom := generic.ROM{Data: input, Size: len(input)}
+			compressed := types.NewMDCompressor(algorithm.Name, rom).Marshal()
+			for size := 0; size < len(compressed); size++ {
+				rom := generic.ROM{Data: compressed[:size], Size: size}
+				func() {
+					defer func() {
+						if r := recover(); r != nil {
+							t.Errorf("Unmarshal() of the first 0x%X of 0x%X bytes panicked: %v", size, len(compressed), r)
+						}
+					}()
+					types.NewMDCompressor(algorithm.Name, rom).Unmarshal()
+				}()
+			}
+		})
+	}
+}
+
+// mdUniqueBytes returns n pseudo-random bytes where no pair of consecutive bytes repeats and no byte
+// equals the previous one, so the data has no match or run of 2 or more bytes and any match found by
+// an encoder is one planted by the test.
+func mdUniqueBytes(n int) []byte {
+	random := rand.New(rand.NewSource(1))
+	used := make(map[[2]byte]bool)
+	out := make([]byte, 0, n)
+	for len(out) < n {
+		value := byte(random.Intn(0x100))
+		if len(out) > 0 {
+			pair := [2]byte{out[len(out)-1], value}
+			if used[pair] || value == pair[0] {
+				continue
+			}
+			used[pair] = true
+		}
+		out = append(out, value)
+	}
+	return out
+}
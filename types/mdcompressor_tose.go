@@ -0,0 +1,22 @@
+package types
+
+import "github.com/hansbonini/go-segamd/types/generic"
+
+// MDCompressor_TOSE is reserved for the in-house scheme of TOSE games. With no published
+// description or dumped block to build it from, it decodes and encodes nothing and is not listed
+// in MDCompressorAlgorithms.
+type MDCompressor_TOSE struct {
+	ROM generic.ROM
+}
+
+func (tose *MDCompressor_TOSE) Marshal() []byte {
+	return []byte{}
+}
+
+func (tose *MDCompressor_TOSE) Unmarshal() []byte {
+	return []byte{}
+}
+
+func (tose *MDCompressor_TOSE) UnmarshalAt(offset int) ([]byte, int) {
+	return []byte{}, 0
+}
@@ -0,0 +1,22 @@
+package types
+
+import "github.com/hansbonini/go-segamd/types/generic"
+
+// MDCompressor_WESTONE is reserved for the Monster World games. Until their compression is reversed
+// and tested on a dumped block, it decodes and encodes nothing and is not listed in
+// MDCompressorAlgorithms, so reinserted data can never be corrupted by a guessed layout.
+type MDCompressor_WESTONE struct {
+	ROM generic.ROM
+}
+
+func (westone *MDCompressor_WESTONE) Marshal() []byte {
+	return []byte{}
+}
+
+func (westone *MDCompressor_WESTONE) Unmarshal() []byte {
+	return []byte{}
+}
+
+func (westone *MDCompressor_WESTONE) UnmarshalAt(offset int) ([]byte, int) {
+	return []byte{}, 0
+}
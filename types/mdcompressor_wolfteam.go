@@ -0,0 +1,22 @@
+package types
+
+import "github.com/hansbonini/go-segamd/types/generic"
+
+// MDCompressor_WOLFTEAM is meant for the LZSS scheme of Granada, Sol-Feace and other Wolf Team
+// games. The fill value and start position of its window are not known from a source or a dumped
+// block, so it decodes and encodes nothing and is not listed in MDCompressorAlgorithms.
+type MDCompressor_WOLFTEAM struct {
+	ROM generic.ROM
+}
+
+func (wolfteam *MDCompressor_WOLFTEAM) Marshal() []byte {
+	return []byte{}
+}
+
+func (wolfteam *MDCompressor_WOLFTEAM) Unmarshal() []byte {
+	return []byte{}
+}
+
+func (wolfteam *MDCompressor_WOLFTEAM) UnmarshalAt(offset int) ([]byte, int) {
+	return []byte{}, 0
+}
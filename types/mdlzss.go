@@ -0,0 +1,156 @@
+package types
+
+import (
+	"errors"
+	"math"
+)
+
+var errLZSSCopy = errors.New("copy starts before the beginning of the output")
+
+const (
+	lzssMaxChain   = 0x400
+	lzssGoodLength = 0x100
+)
+
+type lzssStep struct {
+	Length   int
+	Distance int
+	Kind     int
+}
+
+type lzssMatch struct {
+	Distance int
+	Length   int
+}
+
+type lzssKey[T comparable] struct {
+	First  T
+	Second T
+}
+
+// lzssParse finds the cheapest sequence of commands that encodes data[start:].
+//
+// The data before start is a dictionary that matches may refer to but that is not encoded, which
+// allows formats with pre-filled windows. For each position, the nearest matches within the window
+// are collected, each one longer than the previous, and handed to the edges callback, which must
+// report through add every command the format can emit from that position along with its cost in
+// bits. The returned steps are the shortest path through the resulting graph.
+//
+// To keep large windows fast, at most lzssMaxChain earlier positions are tried for each position,
+// and the search stops as soon as a match of lzssGoodLength elements is found, so the parse is only
+// optimal over the matches actually collected.
+//
+// Parameters:
+// - data: the dictionary followed by the data to encode.
+// - start: the position where encoding starts.
+// - window: the maximum distance of a match.
+// - minLength: the minimum length of a match, used to index the dictionary.
+// - maxLength: the maximum length of a match.
+// - edges: a callback reporting the commands available at a given position.
+//
+// Returns:
+// - []lzssStep: the commands to emit, in order.
+func lzssParse[T comparable](data []T, start, window, minLength, maxLength int, edges func(pos int, matches []lzssMatch, add func(step lzssStep, cost int))) []lzssStep {
+	n := len(data)
+	cost := make([]int, n+1)
+	from := make([]lzssStep, n+1)
+	for i := range cost {
+		cost[i] = math.MaxInt
+	}
+	cost[start] = 0
+
+	head := make(map[lzssKey[T]]int)
+	previous := make([]int, n)
+	key := func(pos int) (lzssKey[T], bool) {
+		if minLength < 2 {
+			return lzssKey[T]{First: data[pos]}, true
+		}
+		if pos+1 >= n {
+			return lzssKey[T]{}, false
+		}
+		return lzssKey[T]{First: data[pos], Second: data[pos+1]}, true
+	}
+	insert := func(pos int) {
+		if k, ok := key(pos); ok {
+			if last, found := head[k]; found {
+				previous[pos] = last
+			} else {
+				previous[pos] = -1
+			}
+			head[k] = pos
+		}
+	}
+	for pos := 0; pos < start; pos++ {
+		insert(pos)
+	}
+
+	matches := make([]lzssMatch, 0)
+	for pos := start; pos < n; pos++ {
+		matches = matches[:0]
+		if cost[pos] != math.MaxInt {
+			limit := min(maxLength, n-pos)
+			if k, ok := key(pos); ok {
+				best := 0
+				candidate, found := head[k]
+				for depth := 0; found && candidate >= 0 && pos-candidate <= window && depth < lzssMaxChain; depth++ {
+					if data[candidate+best] != data[pos+best] {
+						candidate = previous[candidate]
+						continue
+					}
+					length := 0
+					for length < limit && data[candidate+length] == data[pos+length] {
+						length++
+					}
+					if length > best {
+						best = length
+						matches = append(matches, lzssMatch{Distance: pos - candidate, Length: length})
+						if best == limit || best >= lzssGoodLength {
+							break
+						}
+					}
+					candidate = previous[candidate]
+				}
+			}
+			edges(pos, matches, func(step lzssStep, c int) {
+				if next := pos + step.Length; next <= n && cost[pos]+c < cost[next] {
+					cost[next] = cost[pos] + c
+					from[next] = step
+				}
+			})
+		}
+		insert(pos)
+	}
+
+	steps := make([]lzssStep, 0)
+	for pos := n; pos > start; pos -= from[pos].Length {
+		if cost[pos] == math.MaxInt {
+			return nil
+		}
+		steps = append(steps, from[pos])
+	}
+	for i, j := 0, len(steps)-1; i < j; i, j = i+1, j-1 {
+		steps[i], steps[j] = steps[j], steps[i]
+	}
+	return steps
+}
+
+// lzssCopy appends count elements copied from distance elements behind the end of out,
+// one at a time so overlapping copies repeat the data.
+//
+// Parameters:
+// - out: the data decoded so far.
+// - distance: how far behind the end of out the copy starts.
+// - count: the number of elements to copy.
+//
+// Returns:
+// - []T: the data with the copied elements appended.
+// - bool: false if the copy starts before the beginning of out.
+func lzssCopy[T any](out []T, distance, count int) ([]T, bool) {
+	if distance <= 0 || distance > len(out) {
+		return out, false
+	}
+	for i := 0; i < count; i++ {
+		out = append(out, out[len(out)-distance])
+	}
+	return out, true
+}